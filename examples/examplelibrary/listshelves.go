@@ -2,8 +2,10 @@ package examplelibrary
 
 import (
 	"context"
+	"errors"
 
 	"go.einride.tech/aip/pagination"
+	"go.einride.tech/aip/validation"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -13,28 +15,23 @@ func (s *Server) ListShelves(
 	ctx context.Context,
 	request *library.ListShelvesRequest,
 ) (*library.ListShelvesResponse, error) {
-	// Handle request constraints.
-	const (
-		maxPageSize     = 1000
-		defaultPageSize = 100
-	)
-	switch {
-	case request.GetPageSize() < 0:
-		return nil, status.Errorf(codes.InvalidArgument, "page size is negative")
-	case request.GetPageSize() == 0:
-		request.PageSize = defaultPageSize
-	case request.GetPageSize() > maxPageSize:
-		request.PageSize = maxPageSize
-	}
-	// Use pagination.PageToken for offset-based page tokens.
-	pageToken, err := pagination.ParsePageToken(request)
+	// Use pagination.PageToken for offset-based page tokens, and pagination.PageSizePolicy for page size defaults
+	// and limits.
+	pageToken, err := pagination.ParsePageToken(request, pagination.WithPageSizePolicy(pagination.PageSizePolicy{
+		Default: 100,
+		Max:     1000,
+	}))
 	if err != nil {
+		var errValidation *validation.Error
+		if errors.As(err, &errValidation) {
+			return nil, errValidation
+		}
 		return nil, status.Errorf(codes.InvalidArgument, "invalid page token")
 	}
 	// Query the storage.
 	result, err := s.Storage.ListShelves(ctx, &ListShelvesQuery{
 		Offset:   pageToken.Offset,
-		PageSize: request.GetPageSize(),
+		PageSize: pageToken.PageSize,
 	})
 	if err != nil {
 		return nil, err
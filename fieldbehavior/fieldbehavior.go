@@ -2,7 +2,9 @@ package fieldbehavior
 
 import (
 	"fmt"
+	"slices"
 
+	"go.einride.tech/aip/fieldmask"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
@@ -38,7 +40,53 @@ func ClearFields(message proto.Message, behaviorsToClear ...annotations.FieldBeh
 }
 
 // CopyFields copies all fields annotated with any of the provided behaviors from src to dst.
+//
+// Nested messages, elements of repeated message fields and values of map message fields in dst are traversed, and
+// their annotated fields are copied from the corresponding message in src. Map values correspond by key, and elements
+// of repeated fields have no corresponding element in src unless keyed by a field, see WithListKey. Annotated fields
+// of nested messages without a corresponding message in src are cleared. This matches the fields cleared by
+// ClearFields.
 func CopyFields(dst, src proto.Message, behaviorsToCopy ...annotations.FieldBehavior) {
+	CopyFieldsWithOptions(dst, src, behaviorsToCopy)
+}
+
+// CopyOption configures CopyFieldsWithOptions.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	behaviorsToCopy []annotations.FieldBehavior
+	listKeys        map[string]protoreflect.Name
+}
+
+// WithListKey makes the elements of the repeated message field at the path correspond by the value of the key field,
+// such as WithListKey("line_items", "external_reference_id"). Paths into map values include the map key, as in field
+// masks. Elements where the key field is not set have no corresponding element in src. Keys that are not singular
+// fields of the elements are ignored.
+func WithListKey(path, key string) CopyOption {
+	return func(opts *copyOptions) {
+		if segments, err := fieldmask.ParsePath(path); err == nil {
+			path = fieldmask.FormatPath(segments)
+		}
+		if opts.listKeys == nil {
+			opts.listKeys = map[string]protoreflect.Name{}
+		}
+		opts.listKeys[path] = protoreflect.Name(key)
+	}
+}
+
+// CopyFieldsWithOptions copies all fields annotated with any of the provided behaviors from src to dst,
+// in the same manner as CopyFields, with options for how elements of repeated fields correspond.
+func CopyFieldsWithOptions(
+	dst, src proto.Message,
+	behaviorsToCopy []annotations.FieldBehavior,
+	opts ...CopyOption,
+) {
+	options := copyOptions{
+		behaviorsToCopy: behaviorsToCopy,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
 	dstReflect := dst.ProtoReflect()
 	srcReflect := src.ProtoReflect()
 	if dstReflect.Descriptor() != srcReflect.Descriptor() {
@@ -48,15 +96,58 @@ func CopyFields(dst, src proto.Message, behaviorsToCopy ...annotations.FieldBeha
 			srcReflect.Type().Descriptor().FullName(),
 		))
 	}
-	for i := 0; i < dstReflect.Descriptor().Fields().Len(); i++ {
-		dstField := dstReflect.Descriptor().Fields().Get(i)
-		if hasAnyBehavior(Get(dstField), behaviorsToCopy) {
-			srcField := srcReflect.Descriptor().Fields().Get(i)
-			if isMessageFieldPresent(srcReflect, srcField) {
-				dstReflect.Set(dstField, srcReflect.Get(srcField))
+	options.copyFieldsWithBehaviors(dstReflect, srcReflect, nil)
+}
+
+// copyFieldsWithBehaviors copies the annotated fields of dst from src, where path is the path of dst and src.
+func (o *copyOptions) copyFieldsWithBehaviors(dst, src protoreflect.Message, path []string) {
+	for i := 0; i < dst.Descriptor().Fields().Len(); i++ {
+		field := dst.Descriptor().Fields().Get(i)
+		fieldPath := append(slices.Clip(path), string(field.Name()))
+		switch {
+		case hasAnyBehavior(Get(field), o.behaviorsToCopy):
+			if isMessageFieldPresent(src, field) {
+				dst.Set(field, src.Get(field))
 			} else {
-				dstReflect.Clear(dstField)
+				dst.Clear(field)
+			}
+		case !dst.Has(field) || field.Message() == nil:
+			continue
+		// if field is repeated, traverse the nested messages by key
+		case field.IsList():
+			dstList, srcList := dst.Mutable(field).List(), src.Get(field).List()
+			keyField := field.Message().Fields().ByName(o.listKeys[fieldmask.FormatPath(fieldPath)])
+			if keyField != nil && keyField.Cardinality() == protoreflect.Repeated {
+				keyField = nil
 			}
+			for j := 0; j < dstList.Len(); j++ {
+				dstElement := dstList.Get(j).Message()
+				srcElement := dstElement.Type().Zero()
+				if keyField != nil {
+					if k := fieldmask.IndexByKey(srcList, keyField, dstList.Get(j)); k >= 0 {
+						srcElement = srcList.Get(k).Message()
+					}
+				}
+				o.copyFieldsWithBehaviors(dstElement, srcElement, fieldPath)
+			}
+		// if field is map, traverse the nested messages by key
+		case field.IsMap():
+			if field.MapValue().Message() == nil {
+				continue
+			}
+			srcMap := src.Get(field).Map()
+			dst.Mutable(field).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				dstValue := value.Message()
+				srcValue := dstValue.Type().Zero()
+				if srcMap.Has(key) {
+					srcValue = srcMap.Get(key).Message()
+				}
+				o.copyFieldsWithBehaviors(dstValue, srcValue, append(slices.Clip(fieldPath), key.String()))
+				return true
+			})
+		// if field is message, traverse the message
+		default:
+			o.copyFieldsWithBehaviors(dst.Mutable(field).Message(), src.Get(field).Message(), fieldPath)
 		}
 	}
 }
@@ -304,6 +304,213 @@ func TestCopyFields(t *testing.T) {
 			CopyFields(&library.Book{}, &library.Shelf{}, annotations.FieldBehavior_REQUIRED)
 		}))
 	})
+
+	t.Run("copy fields with set field_behavior", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			Field:           "dst",
+			OutputOnlyField: "dst", // has OUTPUT_ONLY field_behavior; should be copied.
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			Field:           "src",
+			OutputOnlyField: "src",
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			Field:           "dst",
+			OutputOnlyField: "src",
+		}
+		CopyFields(dst, src, annotations.FieldBehavior_OUTPUT_ONLY)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
+
+	t.Run("copy fields with set field_behavior on nested message", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+				Field:           "dst",
+				OutputOnlyField: "dst", // has OUTPUT_ONLY field_behavior; should be copied.
+			},
+			OptionalMessage: &syntaxv1.FieldBehaviorMessage{
+				OutputOnlyField: "dst", // has OUTPUT_ONLY field_behavior; not in src, should be cleared.
+			},
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+				Field:           "src",
+				OutputOnlyField: "src",
+			},
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+				Field:           "dst",
+				OutputOnlyField: "src",
+			},
+			OptionalMessage: &syntaxv1.FieldBehaviorMessage{},
+		}
+		CopyFields(dst, src, annotations.FieldBehavior_OUTPUT_ONLY)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
+
+	t.Run("copy fields with set field_behavior on message in repeated", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+				{Field: "dst1", OutputOnlyField: "dst1"}, // no key; should be cleared.
+				{Field: "dst2", OutputOnlyField: "dst2"}, // no key; should be cleared.
+			},
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+				{Field: "src1", OutputOnlyField: "src1"},
+			},
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+				{Field: "dst1"},
+				{Field: "dst2"},
+			},
+		}
+		CopyFields(dst, src, annotations.FieldBehavior_OUTPUT_ONLY)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
+
+	t.Run("copy fields with set field_behavior on message in repeated by key", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+				{Field: "new", OutputOnlyField: "dst-new"}, // no element with key in src; should be cleared.
+				{Field: "b", OutputOnlyField: "dst-b"},
+				{Field: "a", OutputOnlyField: "dst-a"},
+				{OutputOnlyField: "dst"}, // no key; should be cleared.
+			},
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+				{Field: "a", OutputOnlyField: "src-a"},
+				{Field: "b", OutputOnlyField: "src-b"},
+				{OutputOnlyField: "src"},
+			},
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+				{Field: "new"},
+				{Field: "b", OutputOnlyField: "src-b"},
+				{Field: "a", OutputOnlyField: "src-a"},
+				{},
+			},
+		}
+		CopyFieldsWithOptions(
+			dst,
+			src,
+			[]annotations.FieldBehavior{annotations.FieldBehavior_OUTPUT_ONLY},
+			WithListKey("repeated_message", "field"),
+		)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
+
+	t.Run("copy fields with set field_behavior on message in nested repeated by key", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"a.b": {
+					RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+						{Field: "b", OutputOnlyField: "dst-b"},
+						{Field: "a", OutputOnlyField: "dst-a"},
+					},
+				},
+			},
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"a.b": {
+					RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+						{Field: "a", OutputOnlyField: "src-a"},
+						{Field: "b", OutputOnlyField: "src-b"},
+					},
+				},
+			},
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"a.b": {
+					RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{
+						{Field: "b", OutputOnlyField: "src-b"},
+						{Field: "a", OutputOnlyField: "src-a"},
+					},
+				},
+			},
+		}
+		CopyFieldsWithOptions(
+			dst,
+			src,
+			[]annotations.FieldBehavior{annotations.FieldBehavior_OUTPUT_ONLY},
+			WithListKey("map_message.`a.b`.repeated_message", "field"),
+		)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
+
+	t.Run("copy fields with set field_behavior on message in map", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key_1": {Field: "dst1", OutputOnlyField: "dst1"},
+				"key_2": {Field: "dst2", OutputOnlyField: "dst2"}, // no value in src; should be cleared.
+			},
+			StringMap: map[string]string{
+				"string_key": "dst", // not a message type, should not be traversed
+			},
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key_1": {Field: "src1", OutputOnlyField: "src1"},
+				"key_3": {Field: "src3", OutputOnlyField: "src3"}, // no value in dst; should not be added.
+			},
+			StringMap: map[string]string{
+				"string_key": "src",
+			},
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key_1": {Field: "dst1", OutputOnlyField: "src1"},
+				"key_2": {Field: "dst2"},
+			},
+			StringMap: map[string]string{
+				"string_key": "dst",
+			},
+		}
+		CopyFields(dst, src, annotations.FieldBehavior_OUTPUT_ONLY)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
+
+	t.Run("copy field with set field_behavior on oneof message", func(t *testing.T) {
+		t.Parallel()
+		dst := &syntaxv1.FieldBehaviorMessage{
+			Oneof: &syntaxv1.FieldBehaviorMessage_FieldBehaviorMessage{
+				FieldBehaviorMessage: &syntaxv1.FieldBehaviorMessage{
+					Field:           "dst",
+					OutputOnlyField: "dst",
+				},
+			},
+		}
+		src := &syntaxv1.FieldBehaviorMessage{
+			Oneof: &syntaxv1.FieldBehaviorMessage_FieldBehaviorMessage{
+				FieldBehaviorMessage: &syntaxv1.FieldBehaviorMessage{
+					Field:           "src",
+					OutputOnlyField: "src",
+				},
+			},
+		}
+		expected := &syntaxv1.FieldBehaviorMessage{
+			Oneof: &syntaxv1.FieldBehaviorMessage_FieldBehaviorMessage{
+				FieldBehaviorMessage: &syntaxv1.FieldBehaviorMessage{
+					Field:           "dst",
+					OutputOnlyField: "src",
+				},
+			},
+		}
+		CopyFields(dst, src, annotations.FieldBehavior_OUTPUT_ONLY)
+		assert.DeepEqual(t, dst, expected, protocmp.Transform())
+	})
 }
 
 func TestValidateRequiredFields(t *testing.T) {
@@ -394,6 +601,38 @@ func TestValidateRequiredFieldsWithMask(t *testing.T) {
 			),
 		)
 	})
+	t.Run("missing in map value", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(
+			t,
+			ValidateRequiredFieldsWithMask(
+				&syntaxv1.RequiredFieldBehaviorMessage{
+					RequiredField: "required",
+					MapMessage: map[string]*syntaxv1.RequiredFieldBehaviorMessage{
+						"key1": {},
+					},
+				},
+				&fieldmaskpb.FieldMask{Paths: []string{"map_message.key1.required_field"}},
+			),
+			"missing required field: map_message.key1.required_field",
+		)
+	})
+	t.Run("missing in map value with other key in mask", func(t *testing.T) {
+		t.Parallel()
+		assert.NilError(
+			t,
+			ValidateRequiredFieldsWithMask(
+				&syntaxv1.RequiredFieldBehaviorMessage{
+					RequiredField: "required",
+					MapMessage: map[string]*syntaxv1.RequiredFieldBehaviorMessage{
+						"key1": {},
+					},
+				},
+				// the segment after the map field is a map key
+				&fieldmaskpb.FieldMask{Paths: []string{"map_message.required_field"}},
+			),
+		)
+	})
 }
 
 func TestValidateImmutableFieldsWithMask(t *testing.T) {
@@ -445,6 +684,17 @@ func TestValidateImmutableFieldsWithMask(t *testing.T) {
 		err := ValidateImmutableFieldsWithMask(req, req.GetUpdateMask())
 		assert.ErrorContains(t, err, "field is immutable")
 	})
+	t.Run("errors when quoted immutable field set in fieldmask", func(t *testing.T) {
+		t.Parallel()
+		req := &examplefreightv1.UpdateShipmentRequest{
+			Shipment: &examplefreightv1.Shipment{},
+			UpdateMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"shipment.`external_reference_id`"},
+			},
+		}
+		err := ValidateImmutableFieldsWithMask(req, req.GetUpdateMask())
+		assert.ErrorContains(t, err, "field is immutable: shipment.external_reference_id")
+	})
 	t.Run("errors when immutable field set in message", func(t *testing.T) {
 		t.Parallel()
 		req := &examplefreightv1.UpdateShipmentRequest{
@@ -475,6 +725,39 @@ func TestValidateImmutableFieldsWithMask(t *testing.T) {
 		err := ValidateImmutableFieldsWithMask(req, req.GetUpdateMask())
 		assert.ErrorContains(t, err, "field is immutable")
 	})
+	t.Run("errors when immutable field of map value set in fieldmask", func(t *testing.T) {
+		t.Parallel()
+		msg := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key1": {ImmutableField: "immutable"},
+				"a.b":  {ImmutableField: "immutable"},
+			},
+		}
+		err := ValidateImmutableFieldsWithMask(
+			msg,
+			&fieldmaskpb.FieldMask{Paths: []string{"map_message.key1.immutable_field"}},
+		)
+		assert.Error(t, err, "field is immutable: map_message.key1.immutable_field")
+		err = ValidateImmutableFieldsWithMask(
+			msg,
+			&fieldmaskpb.FieldMask{Paths: []string{"map_message.`a.b`.immutable_field"}},
+		)
+		assert.Error(t, err, "field is immutable: map_message.`a.b`.immutable_field")
+	})
+	t.Run("no error when map key not in fieldmask", func(t *testing.T) {
+		t.Parallel()
+		msg := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key1": {ImmutableField: "immutable"},
+			},
+		}
+		// the segment after the map field is a map key
+		err := ValidateImmutableFieldsWithMask(
+			msg,
+			&fieldmaskpb.FieldMask{Paths: []string{"map_message.immutable_field"}},
+		)
+		assert.NilError(t, err)
+	})
 }
 
 func TestValidateImmutableFieldsNotChanged(t *testing.T) {
@@ -909,4 +1192,28 @@ func TestValidateImmutableFieldsNotChanged(t *testing.T) {
 		assert.ErrorContains(t, err, "immutable field cannot be changed")
 		assert.ErrorContains(t, err, "external_reference_id")
 	})
+	t.Run("error when immutable field of map value is in mask and changed", func(t *testing.T) {
+		t.Parallel()
+		old := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key1": {ImmutableField: "immutable-1"},
+			},
+		}
+		updated := &syntaxv1.FieldBehaviorMessage{
+			MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+				"key1": {ImmutableField: "immutable-1-changed"},
+			},
+		}
+		for _, path := range []string{"map_message", "map_message.key1", "map_message.key1.immutable_field"} {
+			err := ValidateImmutableFieldsNotChanged(old, updated, &fieldmaskpb.FieldMask{Paths: []string{path}})
+			assert.Error(t, err, "immutable field cannot be changed: map_message.key1.immutable_field", path)
+		}
+		// the segment after the map field is a map key
+		err := ValidateImmutableFieldsNotChanged(
+			old,
+			updated,
+			&fieldmaskpb.FieldMask{Paths: []string{"map_message.immutable_field"}},
+		)
+		assert.NilError(t, err)
+	})
 }
@@ -10,7 +10,9 @@ package fieldbehavior
 
 import (
 	"fmt"
+	"slices"
 
+	"go.einride.tech/aip/fieldmask"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -32,20 +34,15 @@ import (
 //
 // See: https://aip.dev/203
 func ValidateImmutableFieldsWithMask(m proto.Message, mask *fieldmaskpb.FieldMask) error {
-	return validateImmutableFields(m.ProtoReflect(), mask, "")
+	return validateImmutableFields(m.ProtoReflect(), mask, nil)
 }
 
-func validateImmutableFields(m protoreflect.Message, mask *fieldmaskpb.FieldMask, path string) error {
+func validateImmutableFields(m protoreflect.Message, mask *fieldmaskpb.FieldMask, path []string) error {
 	for i := 0; i < m.Descriptor().Fields().Len(); i++ {
 		field := m.Descriptor().Fields().Get(i)
-		currPath := path
-		if len(currPath) > 0 {
-			currPath += "."
-		}
-
-		currPath += string(field.Name())
+		currPath := append(slices.Clip(path), string(field.Name()))
 		if isImmutable(field) && hasPath(mask, currPath) {
-			return fmt.Errorf("field is immutable: %s", currPath)
+			return fmt.Errorf("field is immutable: %s", fieldmask.FormatPath(currPath))
 		}
 
 		if field.Kind() == protoreflect.MessageKind {
@@ -62,8 +59,10 @@ func validateImmutableFields(m protoreflect.Message, mask *fieldmaskpb.FieldMask
 					continue
 				}
 				var mapErr error
-				value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
-					if err := validateImmutableFields(value.Message(), mask, currPath); err != nil {
+				value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+					// map values are addressed by key, see fieldmask.Validate
+					entryPath := append(slices.Clip(currPath), key.String())
+					if err := validateImmutableFields(value.Message(), mask, entryPath); err != nil {
 						mapErr = err
 						return false
 					}
@@ -115,13 +114,13 @@ func validateImmutableFields(m protoreflect.Message, mask *fieldmaskpb.FieldMask
 //
 // See: https://aip.dev/203
 func ValidateImmutableFieldsNotChanged(old, updated proto.Message, mask *fieldmaskpb.FieldMask) error {
-	return validateImmutableFieldsNotChanged(old.ProtoReflect(), updated.ProtoReflect(), mask, "")
+	return validateImmutableFieldsNotChanged(old.ProtoReflect(), updated.ProtoReflect(), mask, nil)
 }
 
 func validateImmutableFieldsNotChanged(
 	old, updated protoreflect.Message,
 	mask *fieldmaskpb.FieldMask,
-	path string,
+	path []string,
 ) error {
 	if old.Descriptor() != updated.Descriptor() {
 		return fmt.Errorf("old and updated messages have different types")
@@ -129,18 +128,13 @@ func validateImmutableFieldsNotChanged(
 
 	for i := 0; i < updated.Descriptor().Fields().Len(); i++ {
 		field := updated.Descriptor().Fields().Get(i)
-		currPath := path
-		if len(currPath) > 0 {
-			currPath += "."
-		}
-
-		currPath += string(field.Name())
+		currPath := append(slices.Clip(path), string(field.Name()))
 		if isImmutable(field) && hasPathWithPrefix(mask, currPath) {
 			// Check if the immutable field's value has changed
 			oldValue := old.Get(field)
 			updatedValue := updated.Get(field)
 			if !oldValue.Equal(updatedValue) {
-				return fmt.Errorf("immutable field cannot be changed: %s", currPath)
+				return fmt.Errorf("immutable field cannot be changed: %s", fieldmask.FormatPath(currPath))
 			}
 			// Values are equal and entire field is in mask - no need to check nested fields
 			continue
@@ -192,11 +186,12 @@ func validateImmutableFieldsNotChanged(
 				updatedMap.Range(func(key protoreflect.MapKey, updatedVal protoreflect.Value) bool {
 					if oldVal := oldMap.Get(key); oldVal.IsValid() {
 						// Key exists in both maps: compare the values
+						// map values are addressed by key, see fieldmask.Validate
 						if err := validateImmutableFieldsNotChanged(
 							oldVal.Message(),
 							updatedVal.Message(),
 							mask,
-							currPath,
+							append(slices.Clip(currPath), key.String()),
 						); err != nil {
 							mapErr = err
 							return false
@@ -2,7 +2,9 @@ package fieldbehavior
 
 import (
 	"fmt"
+	"slices"
 
+	"go.einride.tech/aip/fieldmask"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -15,7 +17,7 @@ func ValidateRequiredFields(m proto.Message) error {
 	return validateRequiredFields(
 		m.ProtoReflect(),
 		&fieldmaskpb.FieldMask{Paths: []string{"*"}},
-		"",
+		nil,
 	)
 }
 
@@ -29,10 +31,10 @@ func ValidateRequiredFields(m proto.Message) error {
 //
 // See: https://aip.dev/203
 func ValidateRequiredFieldsWithMask(m proto.Message, mask *fieldmaskpb.FieldMask) error {
-	return validateRequiredFields(m.ProtoReflect(), mask, "")
+	return validateRequiredFields(m.ProtoReflect(), mask, nil)
 }
 
-func validateRequiredFields(reflectMessage protoreflect.Message, mask *fieldmaskpb.FieldMask, path string) error {
+func validateRequiredFields(reflectMessage protoreflect.Message, mask *fieldmaskpb.FieldMask, path []string) error {
 	// If no paths are provided, the field mask should be treated to be equivalent
 	// to all fields set on the wire. This means that no required fields can be missing,
 	// since if they were missing they're not set on the wire.
@@ -41,14 +43,10 @@ func validateRequiredFields(reflectMessage protoreflect.Message, mask *fieldmask
 	}
 	for i := 0; i < reflectMessage.Descriptor().Fields().Len(); i++ {
 		field := reflectMessage.Descriptor().Fields().Get(i)
-		currPath := path
-		if len(currPath) > 0 {
-			currPath += "."
-		}
-		currPath += string(field.Name())
+		currPath := append(slices.Clip(path), string(field.Name()))
 		if !isMessageFieldPresent(reflectMessage, field) {
 			if Has(field, annotations.FieldBehavior_REQUIRED) && hasPath(mask, currPath) {
-				return fmt.Errorf("missing required field: %s", currPath)
+				return fmt.Errorf("missing required field: %s", fieldmask.FormatPath(currPath))
 			}
 		} else if field.Kind() == protoreflect.MessageKind {
 			value := reflectMessage.Get(field)
@@ -64,8 +62,10 @@ func validateRequiredFields(reflectMessage protoreflect.Message, mask *fieldmask
 					continue
 				}
 				var mapErr error
-				value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
-					if err := validateRequiredFields(value.Message(), mask, currPath); err != nil {
+				value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+					// map values are addressed by key, see fieldmask.Validate
+					entryPath := append(slices.Clip(currPath), key.String())
+					if err := validateRequiredFields(value.Message(), mask, entryPath); err != nil {
 						mapErr = err
 						return false
 					}
@@ -89,12 +89,15 @@ func isEmpty(mask *fieldmaskpb.FieldMask) bool {
 	return mask == nil || len(mask.GetPaths()) == 0
 }
 
-func hasPath(mask *fieldmaskpb.FieldMask, needle string) bool {
+func hasPath(mask *fieldmaskpb.FieldMask, needleSegments []string) bool {
 	if isEmpty(mask) {
 		return true
 	}
 	for _, straw := range mask.GetPaths() {
-		if straw == "*" || straw == needle {
+		if straw == fieldmask.WildcardPath {
+			return true
+		}
+		if strawSegments, err := fieldmask.ParsePath(straw); err == nil && slices.Equal(strawSegments, needleSegments) {
 			return true
 		}
 	}
@@ -104,16 +107,22 @@ func hasPath(mask *fieldmaskpb.FieldMask, needle string) bool {
 // hasPathWithPrefix checks if a field path is covered by the mask, supporting prefix matching.
 // This enables nested field validation when the mask contains a parent field.
 // For example, if mask contains "line_items", it matches "line_items.external_reference_id".
-func hasPathWithPrefix(mask *fieldmaskpb.FieldMask, needle string) bool {
+//
+// Paths are compared segment by segment, so quoted and unquoted segments are equivalent. See fieldmask.ParsePath.
+func hasPathWithPrefix(mask *fieldmaskpb.FieldMask, needleSegments []string) bool {
 	if isEmpty(mask) {
 		return true
 	}
 	for _, straw := range mask.GetPaths() {
-		if straw == "*" || straw == needle {
+		if straw == fieldmask.WildcardPath {
 			return true
 		}
+		strawSegments, err := fieldmask.ParsePath(straw)
+		if err != nil {
+			continue
+		}
 		// Support prefix matching: if mask contains "line_items", it should match "line_items.external_reference_id"
-		if len(straw) < len(needle) && needle[:len(straw)] == straw && needle[len(straw)] == '.' {
+		if len(strawSegments) <= len(needleSegments) && slices.Equal(strawSegments, needleSegments[:len(strawSegments)]) {
 			return true
 		}
 	}
@@ -0,0 +1,93 @@
+package fieldmask
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Field numbers of google.protobuf.Any.
+const (
+	anyTypeURLField protoreflect.FieldNumber = 1
+	anyValueField   protoreflect.FieldNumber = 2
+)
+
+// TypeResolverOption sets the resolver for the payload types of google.protobuf.Any fields.
+// See WithTypeResolver.
+type TypeResolverOption struct {
+	resolver protoregistry.MessageTypeResolver
+}
+
+// WithTypeResolver sets the resolver for the payload types of google.protobuf.Any fields.
+// The default is protoregistry.GlobalTypes.
+//
+// The same option configures all functions that resolve paths into Any payloads: Validate, ValidateUpdateMask,
+// UpdateWithOptions, Prune, Subtract and Expand.
+func WithTypeResolver(resolver protoregistry.MessageTypeResolver) TypeResolverOption {
+	return TypeResolverOption{resolver: resolver}
+}
+
+func (o TypeResolverOption) applyValidateOption(opts *validateOptions) {
+	opts.resolver = o.resolver
+}
+
+func (o TypeResolverOption) applyUpdateOption(opts *updateOptions) {
+	opts.resolver = o.resolver
+}
+
+func (o TypeResolverOption) applyUpdateMaskOption(opts *updateMaskOptions) {
+	opts.resolver = o.resolver
+}
+
+func (o TypeResolverOption) applyPruneOption(opts *pruneOptions) {
+	opts.resolver = o.resolver
+}
+
+func (o TypeResolverOption) applySubtractOption(opts *subtractOptions) {
+	opts.resolver = o.resolver
+}
+
+func (o TypeResolverOption) applyExpandOption(opts *expandOptions) {
+	opts.resolver = o.resolver
+}
+
+// isAny returns true if the message descriptor is google.protobuf.Any.
+func isAny(md protoreflect.MessageDescriptor) bool {
+	return md != nil && md.FullName() == "google.protobuf.Any"
+}
+
+// unpackAny unpacks the payload of the google.protobuf.Any message, using the resolver to find the payload type.
+func unpackAny(m protoreflect.Message, resolver protoregistry.MessageTypeResolver) (protoreflect.Message, error) {
+	typeURL := anyTypeURL(m)
+	if typeURL == "" {
+		return nil, fmt.Errorf("unpack any: empty type URL")
+	}
+	mt, err := resolver.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, fmt.Errorf("unpack any: %w", err)
+	}
+	payload := mt.New()
+	value := m.Get(m.Descriptor().Fields().ByNumber(anyValueField)).Bytes()
+	if err := proto.Unmarshal(value, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("unpack any: %w", err)
+	}
+	return payload, nil
+}
+
+// packAny packs the payload into the google.protobuf.Any message.
+func packAny(m protoreflect.Message, typeURL string, payload protoreflect.Message) error {
+	value, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload.Interface())
+	if err != nil {
+		return fmt.Errorf("pack any: %w", err)
+	}
+	m.Set(m.Descriptor().Fields().ByNumber(anyTypeURLField), protoreflect.ValueOfString(typeURL))
+	m.Set(m.Descriptor().Fields().ByNumber(anyValueField), protoreflect.ValueOfBytes(value))
+	return nil
+}
+
+// anyTypeURL returns the type URL of the google.protobuf.Any message.
+func anyTypeURL(m protoreflect.Message) string {
+	return m.Get(m.Descriptor().Fields().ByNumber(anyTypeURLField)).String()
+}
@@ -0,0 +1,195 @@
+package fieldmask
+
+import (
+	"testing"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidate_any(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name          string
+		paths         []string
+		details       proto.Message
+		opts          []ValidateOption
+		errorContains string
+	}{
+		{
+			name:    "valid",
+			paths:   []string{"details", "details.title", "details.author"},
+			details: &library.Book{Title: "title"},
+		},
+		{
+			name:          "invalid payload field",
+			paths:         []string{"details.foo"},
+			details:       &library.Book{Title: "title"},
+			errorContains: "invalid field path: details.foo",
+		},
+		{
+			name:          "invalid unset payload",
+			paths:         []string{"details.title"},
+			errorContains: "invalid field path: details.title",
+		},
+		{
+			name:          "invalid repeated payload",
+			paths:         []string{"repeated_details.title"},
+			details:       &library.Book{Title: "title"},
+			errorContains: "invalid field path: repeated_details.title",
+		},
+		{
+			name:          "invalid unknown payload type",
+			paths:         []string{"details.title"},
+			details:       &library.Book{Title: "title"},
+			opts:          []ValidateOption{WithTypeResolver(&protoregistry.Types{})},
+			errorContains: "invalid field path: details.title",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newAnyMessage(tt.details)
+			err := Validate(&fieldmaskpb.FieldMask{Paths: tt.paths}, m, tt.opts...)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdate_any(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		paths    []string
+		src      proto.Message
+		dst      proto.Message
+		expected proto.Message
+	}{
+		{
+			name:     "payload field",
+			paths:    []string{"details.title"},
+			src:      &library.Book{Title: "src", Author: "src"},
+			dst:      &library.Book{Title: "dst", Author: "dst"},
+			expected: &library.Book{Title: "src", Author: "dst"},
+		},
+		{
+			name:     "payload field cleared",
+			paths:    []string{"details.title"},
+			src:      nil,
+			dst:      &library.Book{Title: "dst", Author: "dst"},
+			expected: &library.Book{Author: "dst"},
+		},
+		{
+			name:     "payload of different type",
+			paths:    []string{"details.title"},
+			src:      &library.Book{Title: "src", Author: "src"},
+			dst:      &library.Shelf{Name: "shelves/1", Theme: "dst"},
+			expected: &library.Book{Title: "src"},
+		},
+		{
+			name:     "payload in dst not set",
+			paths:    []string{"details.title"},
+			src:      &library.Book{Title: "src", Author: "src"},
+			dst:      nil,
+			expected: &library.Book{Title: "src"},
+		},
+		{
+			name:     "payload not set",
+			paths:    []string{"details.title"},
+			src:      nil,
+			dst:      nil,
+			expected: nil,
+		},
+		{
+			name:     "whole field",
+			paths:    []string{"details"},
+			src:      &library.Book{Title: "src", Author: "src"},
+			dst:      &library.Shelf{Name: "shelves/1", Theme: "dst"},
+			expected: &library.Book{Title: "src", Author: "src"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dst := newAnyMessage(tt.dst)
+			Update(&fieldmaskpb.FieldMask{Paths: tt.paths}, dst, newAnyMessage(tt.src))
+			assert.DeepEqual(t, newAnyMessage(tt.expected), dst, protocmp.Transform())
+		})
+	}
+}
+
+func TestPrune_any(t *testing.T) {
+	t.Parallel()
+	t.Run("payload field", func(t *testing.T) {
+		t.Parallel()
+		m := newAnyMessage(&library.Book{Title: "title", Author: "author"})
+		Prune(&fieldmaskpb.FieldMask{Paths: []string{"details.title"}}, m)
+		assert.DeepEqual(t, newAnyMessage(&library.Book{Title: "title"}), m, protocmp.Transform())
+	})
+	t.Run("unknown payload type", func(t *testing.T) {
+		t.Parallel()
+		m := &syntaxv1.AnyMessage{Details: &anypb.Any{TypeUrl: "type.googleapis.com/foo.Bar", Value: []byte{1}}}
+		Prune(&fieldmaskpb.FieldMask{Paths: []string{"details.title"}}, m)
+		assert.DeepEqual(t, &syntaxv1.AnyMessage{Details: &anypb.Any{}}, m, protocmp.Transform())
+	})
+}
+
+func TestWithTypeResolver(t *testing.T) {
+	t.Parallel()
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"details.title"}}
+	var bookTypes protoregistry.Types
+	assert.NilError(t, bookTypes.RegisterMessage((&library.Book{}).ProtoReflect().Type()))
+	emptyTypes := WithTypeResolver(&protoregistry.Types{})
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+		m := newAnyMessage(&library.Book{Title: "title"})
+		assert.NilError(t, Validate(mask, m, WithTypeResolver(&bookTypes)))
+		assert.ErrorContains(t, Validate(mask, m, emptyTypes), "invalid field path: details.title")
+	})
+	t.Run("validate update mask", func(t *testing.T) {
+		t.Parallel()
+		m := newAnyMessage(&library.Book{Title: "title"})
+		_, err := ValidateUpdateMask(mask, m, WithTypeResolver(&bookTypes))
+		assert.NilError(t, err)
+		_, err = ValidateUpdateMask(mask, m, emptyTypes)
+		assert.ErrorContains(t, err, "invalid field path: details.title")
+	})
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+		src := newAnyMessage(&library.Book{Title: "src"})
+		dst := newAnyMessage(&library.Book{Title: "dst"})
+		UpdateWithOptions(mask, dst, src, emptyTypes)
+		assert.DeepEqual(t, newAnyMessage(&library.Book{Title: "dst"}), dst, protocmp.Transform())
+		UpdateWithOptions(mask, dst, src, WithTypeResolver(&bookTypes))
+		assert.DeepEqual(t, newAnyMessage(&library.Book{Title: "src"}), dst, protocmp.Transform())
+	})
+	t.Run("prune", func(t *testing.T) {
+		t.Parallel()
+		m := newAnyMessage(&library.Book{Title: "title", Author: "author"})
+		Prune(mask, m, WithTypeResolver(&bookTypes))
+		assert.DeepEqual(t, newAnyMessage(&library.Book{Title: "title"}), m, protocmp.Transform())
+		Prune(mask, m, emptyTypes)
+		assert.DeepEqual(t, &syntaxv1.AnyMessage{Details: &anypb.Any{}}, m, protocmp.Transform())
+	})
+}
+
+// newAnyMessage returns a message with the details field set to the payload if not nil.
+func newAnyMessage(payload proto.Message) *syntaxv1.AnyMessage {
+	m := &syntaxv1.AnyMessage{}
+	if payload != nil {
+		details, err := anypb.New(payload)
+		if err != nil {
+			panic(err)
+		}
+		m.Details = details
+	}
+	return m
+}
@@ -0,0 +1,144 @@
+package fieldmask
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// DiffOption configures Diff.
+type DiffOption func(*diffOptions)
+
+type diffOptions struct {
+	maxDepth   int
+	mapEntries bool
+}
+
+// WithMaxDepth limits the number of segments of the paths returned by Diff.
+//
+// Nested messages at the depth limit are compared as a whole, and a differing nested message results in a single
+// path to the message field. The default is no limit.
+func WithMaxDepth(depth int) DiffOption {
+	return func(opts *diffOptions) {
+		opts.maxDepth = depth
+	}
+}
+
+// WithMapEntries makes Diff return one path per differing map entry, such as labels.env,
+// instead of a single path to the map field. See Update for how map entry paths are applied.
+func WithMapEntries() DiffOption {
+	return func(opts *diffOptions) {
+		opts.mapEntries = true
+	}
+}
+
+// Diff returns a field mask with the paths of the fields that differ between old and updated.
+//
+// The field mask is minimal in the sense that nested messages set in both old and updated are compared field by
+// field, while a nested message set in only one of them results in a single path to the message field. Repeated
+// fields are always compared as a whole, since field mask paths can not refer to individual elements of repeated
+// fields.
+// Map fields are compared as a whole, unless WithMapEntries is provided.
+//
+// Updating old with updated according to the returned field mask makes old equal to updated, see Update.
+// When old and updated are equal, the returned field mask has no paths, which Update does not treat as a no-op,
+// so callers should skip the update instead.
+//
+// Paths are returned in field declaration order. Panics if old and updated are of different types.
+func Diff(old, updated proto.Message, opts ...DiffOption) *fieldmaskpb.FieldMask {
+	var options diffOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	oldReflect := old.ProtoReflect()
+	updatedReflect := updated.ProtoReflect()
+	if oldReflect.Descriptor() != updatedReflect.Descriptor() {
+		panic(fmt.Sprintf(
+			"old (%s) and updated (%s) messages have different types",
+			oldReflect.Descriptor().FullName(),
+			updatedReflect.Descriptor().FullName(),
+		))
+	}
+	result := &fieldmaskpb.FieldMask{}
+	diffMessages(&options, oldReflect, updatedReflect, nil, &result.Paths)
+	return result
+}
+
+func diffMessages(options *diffOptions, old, updated protoreflect.Message, prefix []string, paths *[]string) {
+	fields := updated.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		path := append(slices.Clip(prefix), string(field.Name()))
+		oldHas, updatedHas := old.Has(field), updated.Has(field)
+		switch {
+		case !oldHas && !updatedHas:
+			continue
+		case field.IsMap() && options.mapEntries:
+			diffMapEntries(field, old.Get(field).Map(), updated.Get(field).Map(), path, paths)
+		case oldHas != updatedHas:
+			*paths = append(*paths, FormatPath(path))
+		case field.IsList() || field.IsMap():
+			if !old.Get(field).Equal(updated.Get(field)) {
+				*paths = append(*paths, FormatPath(path))
+			}
+		case field.Message() != nil && (options.maxDepth <= 0 || len(path) < options.maxDepth):
+			diffMessages(options, old.Get(field).Message(), updated.Get(field).Message(), path, paths)
+		default:
+			if !old.Get(field).Equal(updated.Get(field)) {
+				*paths = append(*paths, FormatPath(path))
+			}
+		}
+	}
+}
+
+func diffMapEntries(
+	field protoreflect.FieldDescriptor,
+	old, updated protoreflect.Map,
+	prefix []string,
+	paths *[]string,
+) {
+	keys := make([]protoreflect.MapKey, 0, old.Len()+updated.Len())
+	old.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		if !updated.Has(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	updated.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		if !old.Has(key) || !old.Get(key).Equal(value) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	slices.SortFunc(keys, func(x, y protoreflect.MapKey) int {
+		return compareMapKeys(field.MapKey(), x, y)
+	})
+	for _, key := range keys {
+		*paths = append(*paths, FormatPath(append(slices.Clip(prefix), key.String())))
+	}
+}
+
+func compareMapKeys(fd protoreflect.FieldDescriptor, x, y protoreflect.MapKey) int {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		switch {
+		case x.Bool() == y.Bool():
+			return 0
+		case !x.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return cmp.Compare(x.Int(), y.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return cmp.Compare(x.Uint(), y.Uint())
+	default:
+		return cmp.Compare(x.String(), y.String())
+	}
+}
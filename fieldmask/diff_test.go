@@ -0,0 +1,160 @@
+package fieldmask
+
+import (
+	"testing"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	t.Run("should panic on different old and updated", func(t *testing.T) {
+		t.Parallel()
+		assert.Assert(t, cmp.Panics(func() {
+			Diff(&library.Book{}, &library.Shelf{})
+		}))
+	})
+
+	for _, tt := range []struct {
+		name     string
+		old      proto.Message
+		updated  proto.Message
+		opts     []DiffOption
+		expected []string
+	}{
+		{
+			name:    "equal",
+			old:     &library.Book{Name: "shelves/1/books/1", Title: "title"},
+			updated: &library.Book{Name: "shelves/1/books/1", Title: "title"},
+		},
+		{
+			name:     "scalars",
+			old:      &library.Book{Name: "shelves/1/books/1", Title: "old", Author: "author"},
+			updated:  &library.Book{Name: "shelves/1/books/1", Title: "new", Read: true},
+			expected: []string{"author", "title", "read"},
+		},
+		{
+			name: "nested",
+			old: &syntaxv1.Message{
+				Message: &syntaxv1.Message{String_: "old", Int64: 1},
+			},
+			updated: &syntaxv1.Message{
+				Message: &syntaxv1.Message{String_: "new", Int64: 1},
+			},
+			expected: []string{"message.string"},
+		},
+		{
+			name: "nested deep",
+			old: &syntaxv1.Message{
+				Message: &syntaxv1.Message{Message: &syntaxv1.Message{Bool: true}},
+			},
+			updated: &syntaxv1.Message{
+				Message: &syntaxv1.Message{Message: &syntaxv1.Message{Int32: 1}},
+			},
+			expected: []string{"message.message.int32", "message.message.bool"},
+		},
+		{
+			name: "nested max depth",
+			old: &syntaxv1.Message{
+				Message: &syntaxv1.Message{Message: &syntaxv1.Message{Bool: true}, String_: "old"},
+			},
+			updated: &syntaxv1.Message{
+				Message: &syntaxv1.Message{Message: &syntaxv1.Message{Int32: 1}, String_: "new"},
+			},
+			opts:     []DiffOption{WithMaxDepth(2)},
+			expected: []string{"message.string", "message.message"},
+		},
+		{
+			name:     "nested set",
+			old:      &syntaxv1.Message{},
+			updated:  &syntaxv1.Message{Message: &syntaxv1.Message{String_: "new"}},
+			expected: []string{"message"},
+		},
+		{
+			name:     "nested cleared",
+			old:      &syntaxv1.Message{Message: &syntaxv1.Message{}},
+			updated:  &syntaxv1.Message{},
+			expected: []string{"message"},
+		},
+		{
+			name:     "repeated",
+			old:      &syntaxv1.Message{RepeatedString: []string{"a", "b"}},
+			updated:  &syntaxv1.Message{RepeatedString: []string{"a", "c"}},
+			expected: []string{"repeated_string"},
+		},
+		{
+			name:     "repeated message",
+			old:      &syntaxv1.Message{RepeatedMessage: []*syntaxv1.Message{{String_: "a"}}},
+			updated:  &syntaxv1.Message{RepeatedMessage: []*syntaxv1.Message{{String_: "b"}}},
+			expected: []string{"repeated_message"},
+		},
+		{
+			name:     "repeated equal",
+			old:      &syntaxv1.Message{RepeatedMessage: []*syntaxv1.Message{{String_: "a"}}},
+			updated:  &syntaxv1.Message{RepeatedMessage: []*syntaxv1.Message{{String_: "a"}}},
+			expected: nil,
+		},
+		{
+			name: "map",
+			old: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "b": "2"},
+			},
+			updated: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "b": "3"},
+			},
+			expected: []string{"map_string_string"},
+		},
+		{
+			name: "map entries",
+			old: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "b": "2", "c": "3"},
+			},
+			updated: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "b": "4", "example.com/d": "5"},
+			},
+			opts:     []DiffOption{WithMapEntries()},
+			expected: []string{"map_string_string.b", "map_string_string.c", "map_string_string.`example.com/d`"},
+		},
+		{
+			name: "map message entries",
+			old: &syntaxv1.Message{
+				MapStringMessage: map[string]*syntaxv1.Message{"a": {String_: "1"}},
+			},
+			updated: &syntaxv1.Message{
+				MapStringMessage: map[string]*syntaxv1.Message{"a": {String_: "2"}},
+			},
+			opts:     []DiffOption{WithMapEntries()},
+			expected: []string{"map_string_message.a"},
+		},
+		{
+			name: "oneof",
+			old: &syntaxv1.Message{
+				Oneof: &syntaxv1.Message_OneofString{OneofString: "old"},
+			},
+			updated: &syntaxv1.Message{
+				Oneof: &syntaxv1.Message_OneofMessage1{OneofMessage1: &syntaxv1.Message{String_: "new"}},
+			},
+			expected: []string{"oneof_string", "oneof_message1"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Diff(tt.old, tt.updated, tt.opts...)
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, actual, protocmp.Transform())
+			if len(actual.GetPaths()) == 0 {
+				assert.DeepEqual(t, tt.updated, tt.old, protocmp.Transform())
+				return
+			}
+			// Updating old according to the diff should result in updated.
+			result := proto.Clone(tt.old)
+			Update(actual, result, tt.updated)
+			assert.DeepEqual(t, tt.updated, result, protocmp.Transform())
+		})
+	}
+}
@@ -5,4 +5,5 @@
 //
 // See: https://google.aip.dev/134 (Standard methods: Update)
 // See: https://google.aip.dev/157 (Partial responses)
+// See: https://google.aip.dev/161 (Field masks)
 package fieldmask
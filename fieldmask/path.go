@@ -0,0 +1,144 @@
+package fieldmask
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ParsePath parses a field mask path into its segments.
+//
+// Segments are separated by dots. Segments that are not valid identifiers, such as map keys containing dots, are
+// quoted with backticks, and literal backticks within quoted segments are escaped by doubling them. For example,
+// labels.`example.com/owner` is parsed into the segments labels and example.com/owner.
+//
+// See: https://google.aip.dev/161 (Field masks).
+func ParsePath(path string) ([]string, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse field path '%s': %w", path, err)
+	}
+	return segments, nil
+}
+
+func parsePath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var segments []string
+	for {
+		var segment string
+		if strings.HasPrefix(path, "`") {
+			var b strings.Builder
+			i := 1
+			for {
+				j := strings.IndexByte(path[i:], '`')
+				if j < 0 {
+					return nil, fmt.Errorf("unterminated quoted segment")
+				}
+				_, _ = b.WriteString(path[i : i+j])
+				i += j + 1
+				if i < len(path) && path[i] == '`' {
+					_ = b.WriteByte('`') // escaped backtick
+					i++
+					continue
+				}
+				break
+			}
+			segment, path = b.String(), path[i:]
+			if path != "" && path[0] != '.' {
+				return nil, fmt.Errorf("unexpected character after quoted segment")
+			}
+		} else {
+			if i := strings.IndexByte(path, '.'); i >= 0 {
+				segment, path = path[:i], path[i:]
+			} else {
+				segment, path = path, ""
+			}
+			if segment == "" {
+				return nil, fmt.Errorf("empty segment")
+			}
+			if strings.ContainsRune(segment, '`') {
+				return nil, fmt.Errorf("unexpected backtick in unquoted segment")
+			}
+		}
+		segments = append(segments, segment)
+		if path == "" {
+			return segments, nil
+		}
+		path = path[1:] // skip the dot
+		if path == "" {
+			return nil, fmt.Errorf("empty segment")
+		}
+	}
+}
+
+// FormatPath formats the segments of a field mask path, the inverse of ParsePath.
+//
+// Segments that consist of anything other than letters, digits and underscores are quoted with backticks,
+// and literal backticks are escaped by doubling them. The wildcard segment * is never quoted.
+func FormatPath(segments []string) string {
+	var b strings.Builder
+	for i, segment := range segments {
+		if i > 0 {
+			_ = b.WriteByte('.')
+		}
+		if !needsQuoting(segment) {
+			_, _ = b.WriteString(segment)
+			continue
+		}
+		_ = b.WriteByte('`')
+		_, _ = b.WriteString(strings.ReplaceAll(segment, "`", "``"))
+		_ = b.WriteByte('`')
+	}
+	return b.String()
+}
+
+func needsQuoting(segment string) bool {
+	switch segment {
+	case "":
+		return true
+	case WildcardPath:
+		return false
+	}
+	for _, r := range segment {
+		if !(r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMapKey parses a path segment as a key of a map field.
+func parseMapKey(fd protoreflect.FieldDescriptor, segment string) (protoreflect.MapKey, bool) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(segment).MapKey(), true
+	case protoreflect.BoolKind:
+		switch segment {
+		case "true":
+			return protoreflect.ValueOfBool(true).MapKey(), true
+		case "false":
+			return protoreflect.ValueOfBool(false).MapKey(), true
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i, err := strconv.ParseInt(segment, 10, 32); err == nil {
+			return protoreflect.ValueOfInt32(int32(i)).MapKey(), true
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if i, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			return protoreflect.ValueOfInt64(i).MapKey(), true
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if i, err := strconv.ParseUint(segment, 10, 32); err == nil {
+			return protoreflect.ValueOfUint32(uint32(i)).MapKey(), true
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if i, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			return protoreflect.ValueOfUint64(i).MapKey(), true
+		}
+	}
+	return protoreflect.MapKey{}, false
+}
@@ -0,0 +1,143 @@
+package fieldmask
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name          string
+		path          string
+		expected      []string
+		errorContains string
+	}{
+		{
+			name:     "single",
+			path:     "name",
+			expected: []string{"name"},
+		},
+		{
+			name:     "nested",
+			path:     "book.author",
+			expected: []string{"book", "author"},
+		},
+		{
+			name:     "wildcard",
+			path:     "*",
+			expected: []string{"*"},
+		},
+		{
+			name:     "quoted",
+			path:     "labels.`example.com/owner`",
+			expected: []string{"labels", "example.com/owner"},
+		},
+		{
+			name:     "quoted with escaped backtick",
+			path:     "labels.`a``b`",
+			expected: []string{"labels", "a`b"},
+		},
+		{
+			name:     "quoted first segment",
+			path:     "`labels`.env",
+			expected: []string{"labels", "env"},
+		},
+		{
+			name:     "quoted empty",
+			path:     "labels.``",
+			expected: []string{"labels", ""},
+		},
+		{
+			name:          "empty",
+			path:          "",
+			errorContains: "parse field path '': empty path",
+		},
+		{
+			name:          "empty segment",
+			path:          "book..author",
+			errorContains: "empty segment",
+		},
+		{
+			name:          "trailing dot",
+			path:          "book.",
+			errorContains: "empty segment",
+		},
+		{
+			name:          "unterminated quote",
+			path:          "labels.`env",
+			errorContains: "unterminated quoted segment",
+		},
+		{
+			name:          "character after quote",
+			path:          "labels.`env`x",
+			errorContains: "unexpected character after quoted segment",
+		},
+		{
+			name:          "backtick in unquoted segment",
+			path:          "labels.e`nv",
+			errorContains: "unexpected backtick in unquoted segment",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParsePath(tt.path)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		segments []string
+		expected string
+	}{
+		{
+			name:     "single",
+			segments: []string{"name"},
+			expected: "name",
+		},
+		{
+			name:     "nested",
+			segments: []string{"book", "author"},
+			expected: "book.author",
+		},
+		{
+			name:     "wildcard",
+			segments: []string{"book", "*"},
+			expected: "book.*",
+		},
+		{
+			name:     "quoted",
+			segments: []string{"labels", "example.com/owner"},
+			expected: "labels.`example.com/owner`",
+		},
+		{
+			name:     "escaped backtick",
+			segments: []string{"labels", "a`b"},
+			expected: "labels.`a``b`",
+		},
+		{
+			name:     "empty segment",
+			segments: []string{"labels", ""},
+			expected: "labels.``",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual := FormatPath(tt.segments)
+			assert.Equal(t, tt.expected, actual)
+			parsed, err := ParsePath(actual)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.segments, parsed)
+		})
+	}
+}
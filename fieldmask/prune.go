@@ -0,0 +1,154 @@
+package fieldmask
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// PruneOption configures Prune. See WithTypeResolver.
+type PruneOption interface {
+	applyPruneOption(*pruneOptions)
+}
+
+type pruneOptions struct {
+	resolver protoregistry.MessageTypeResolver
+}
+
+// Prune clears all fields of the message that are not covered by the provided field mask.
+//
+// Paths have the same semantics as in Validate. Paths into nested messages keep only the named fields of the nested
+// message, and paths into repeated message fields keep only the named fields of each element. Paths into map fields
+// refer to map entries by key, and entries not covered by the field mask are removed.
+//
+// If no field mask is provided, or the field mask is the special value "*", the message is left unchanged.
+// Invalid paths do not cover any fields, so the field mask should be validated first. See Validate.
+//
+// Paths into google.protobuf.Any fields refer to fields of the payload, and payloads of unknown types are cleared.
+//
+// See: https://google.aip.dev/157 (Partial responses).
+func Prune(mask *fieldmaskpb.FieldMask, m proto.Message, opts ...PruneOption) {
+	options := pruneOptions{
+		resolver: protoregistry.GlobalTypes,
+	}
+	for _, opt := range opts {
+		opt.applyPruneOption(&options)
+	}
+	if len(mask.GetPaths()) == 0 || stringsContain(WildcardPath, mask.GetPaths()) {
+		return
+	}
+	tree := pathTree{}
+	for _, path := range mask.GetPaths() {
+		segments, err := ParsePath(path)
+		if err != nil {
+			// invalid path syntax
+			continue
+		}
+		tree.insert(segments)
+	}
+	options.pruneMessage(m.ProtoReflect(), tree)
+}
+
+// pathTree is a tree of field mask path segments, where an empty tree covers all fields.
+type pathTree map[string]pathTree
+
+func (t pathTree) insert(segments []string) {
+	for i, segment := range segments {
+		child, ok := t[segment]
+		switch {
+		case ok && len(child) == 0:
+			return // already covered by a parent path
+		case i == len(segments)-1:
+			t[segment] = pathTree{}
+			return
+		case !ok:
+			child = pathTree{}
+			t[segment] = child
+		}
+		t = child
+	}
+}
+
+func (o *pruneOptions) pruneMessage(m protoreflect.Message, tree pathTree) {
+	if isAny(m.Descriptor()) {
+		o.pruneAnyPayload(m, tree)
+		return
+	}
+	var toClear []protoreflect.FieldDescriptor
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		child, ok := tree[fieldPathName(field)]
+		switch {
+		case !ok:
+			toClear = append(toClear, field)
+		case len(child) == 0:
+			// all fields covered
+		case field.IsMap():
+			o.pruneMap(field, value.Map(), child)
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				o.pruneMessage(list.Get(i).Message(), child)
+			}
+		case field.Message() != nil:
+			o.pruneMessage(value.Message(), child)
+		default:
+			// invalid path into a scalar field
+			toClear = append(toClear, field)
+		}
+		return true
+	})
+	for _, field := range toClear {
+		m.Clear(field)
+	}
+}
+
+func (o *pruneOptions) pruneMap(field protoreflect.FieldDescriptor, m protoreflect.Map, tree pathTree) {
+	entries := make(map[any]pathTree, len(tree))
+	for segment, child := range tree {
+		if key, ok := parseMapKey(field.MapKey(), segment); ok {
+			entries[key.Interface()] = child
+		}
+	}
+	var toClear []protoreflect.MapKey
+	m.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		child, ok := entries[key.Interface()]
+		switch {
+		case !ok:
+			toClear = append(toClear, key)
+		case len(child) == 0:
+			// all fields covered
+		case field.MapValue().Message() != nil:
+			o.pruneMessage(value.Message(), child)
+		default:
+			// invalid path into a scalar map value
+			toClear = append(toClear, key)
+		}
+		return true
+	})
+	for _, key := range toClear {
+		m.Clear(key)
+	}
+}
+
+// pruneAnyPayload prunes the payload of the google.protobuf.Any message.
+// The message is cleared if the payload can not be unpacked and packed again.
+func (o *pruneOptions) pruneAnyPayload(m protoreflect.Message, tree pathTree) {
+	payload, err := unpackAny(m, o.resolver)
+	if err == nil {
+		o.pruneMessage(payload, tree)
+		err = packAny(m, anyTypeURL(m), payload)
+	}
+	if err != nil {
+		m.Clear(m.Descriptor().Fields().ByNumber(anyTypeURLField))
+		m.Clear(m.Descriptor().Fields().ByNumber(anyValueField))
+	}
+}
+
+// fieldPathName returns the name of the field in field mask paths, which for groups is the message name.
+func fieldPathName(field protoreflect.FieldDescriptor) string {
+	if field.Kind() == protoreflect.GroupKind {
+		return string(field.Message().Name())
+	}
+	return string(field.Name())
+}
@@ -0,0 +1,147 @@
+package fieldmask
+
+import (
+	"testing"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		paths    []string
+		message  proto.Message
+		expected proto.Message
+	}{
+		{
+			name:     "no mask",
+			message:  &library.Book{Name: "shelves/1/books/1", Title: "title"},
+			expected: &library.Book{Name: "shelves/1/books/1", Title: "title"},
+		},
+		{
+			name:     "wildcard",
+			paths:    []string{"*"},
+			message:  &library.Book{Name: "shelves/1/books/1", Title: "title"},
+			expected: &library.Book{Name: "shelves/1/books/1", Title: "title"},
+		},
+		{
+			name:     "top-level",
+			paths:    []string{"name", "author"},
+			message:  &library.Book{Name: "shelves/1/books/1", Title: "title", Author: "author", Read: true},
+			expected: &library.Book{Name: "shelves/1/books/1", Author: "author"},
+		},
+		{
+			name:  "nested",
+			paths: []string{"message.string"},
+			message: &syntaxv1.Message{
+				String_: "a",
+				Message: &syntaxv1.Message{String_: "b", Int64: 1},
+			},
+			expected: &syntaxv1.Message{
+				Message: &syntaxv1.Message{String_: "b"},
+			},
+		},
+		{
+			name:  "nested covered by parent",
+			paths: []string{"message.string", "message"},
+			message: &syntaxv1.Message{
+				String_: "a",
+				Message: &syntaxv1.Message{String_: "b", Int64: 1},
+			},
+			expected: &syntaxv1.Message{
+				Message: &syntaxv1.Message{String_: "b", Int64: 1},
+			},
+		},
+		{
+			name:  "repeated message",
+			paths: []string{"repeated_message.string"},
+			message: &syntaxv1.Message{
+				RepeatedString: []string{"a"},
+				RepeatedMessage: []*syntaxv1.Message{
+					{String_: "a", Int64: 1},
+					{String_: "b", Int64: 2},
+				},
+			},
+			expected: &syntaxv1.Message{
+				RepeatedMessage: []*syntaxv1.Message{
+					{String_: "a"},
+					{String_: "b"},
+				},
+			},
+		},
+		{
+			name:  "map",
+			paths: []string{"map_string_string"},
+			message: &syntaxv1.Message{
+				String_:         "a",
+				MapStringString: map[string]string{"a": "1", "b": "2"},
+			},
+			expected: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "b": "2"},
+			},
+		},
+		{
+			name:  "map entries",
+			paths: []string{"map_string_string.a", "map_string_string.`example.com/c`"},
+			message: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "b": "2", "example.com/c": "3"},
+			},
+			expected: &syntaxv1.Message{
+				MapStringString: map[string]string{"a": "1", "example.com/c": "3"},
+			},
+		},
+		{
+			name:  "map message values",
+			paths: []string{"map_string_message.a.string", "map_string_message.b"},
+			message: &syntaxv1.Message{
+				MapStringMessage: map[string]*syntaxv1.Message{
+					"a": {String_: "1", Int64: 1},
+					"b": {String_: "2", Int64: 2},
+					"c": {String_: "3", Int64: 3},
+				},
+			},
+			expected: &syntaxv1.Message{
+				MapStringMessage: map[string]*syntaxv1.Message{
+					"a": {String_: "1"},
+					"b": {String_: "2", Int64: 2},
+				},
+			},
+		},
+		{
+			name:  "oneof",
+			paths: []string{"oneof_message1.string"},
+			message: &syntaxv1.Message{
+				Oneof: &syntaxv1.Message_OneofMessage1{
+					OneofMessage1: &syntaxv1.Message{String_: "a", Int64: 1},
+				},
+			},
+			expected: &syntaxv1.Message{
+				Oneof: &syntaxv1.Message_OneofMessage1{
+					OneofMessage1: &syntaxv1.Message{String_: "a"},
+				},
+			},
+		},
+		{
+			name:     "invalid paths",
+			paths:    []string{"string.foo", "foo", "int64..bar"},
+			message:  &syntaxv1.Message{String_: "a", Int64: 1},
+			expected: &syntaxv1.Message{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mask *fieldmaskpb.FieldMask
+			if tt.paths != nil {
+				mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			Prune(mask, tt.message)
+			assert.DeepEqual(t, tt.expected, tt.message, protocmp.Transform())
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package fieldmask
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Union returns a field mask with the paths covered by any of the provided field masks.
+//
+// The result is normalized, see Normalize. If any of the field masks is the special value "*",
+// the result is the special value "*".
+func Union(masks ...*fieldmaskpb.FieldMask) *fieldmaskpb.FieldMask {
+	var paths []string
+	for _, mask := range masks {
+		paths = append(paths, mask.GetPaths()...)
+	}
+	return Normalize(&fieldmaskpb.FieldMask{Paths: paths})
+}
+
+// Intersect returns a field mask with the paths covered by both of the provided field masks.
+//
+// The result is normalized, see Normalize. The special value "*" covers all paths, so the intersection of "*" and
+// another field mask is the other field mask.
+func Intersect(a, b *fieldmaskpb.FieldMask) *fieldmaskpb.FieldMask {
+	switch {
+	case stringsContain(WildcardPath, a.GetPaths()):
+		return Normalize(b)
+	case stringsContain(WildcardPath, b.GetPaths()):
+		return Normalize(a)
+	}
+	aPaths, bPaths := parseMaskPaths(a), parseMaskPaths(b)
+	var paths []string
+	for _, path := range aPaths {
+		if isCovered(path, bPaths) {
+			paths = append(paths, path.text)
+		}
+	}
+	for _, path := range bPaths {
+		if isCovered(path, aPaths) {
+			paths = append(paths, path.text)
+		}
+	}
+	return Normalize(&fieldmaskpb.FieldMask{Paths: paths})
+}
+
+// SubtractOption configures Subtract. See WithTypeResolver.
+type SubtractOption interface {
+	applySubtractOption(*subtractOptions)
+}
+
+type subtractOptions struct {
+	resolver protoregistry.MessageTypeResolver
+}
+
+// Subtract returns a field mask with the paths covered by a but not by b.
+//
+// Paths of a that have some of their subfields covered by b are expanded into the subfields not covered by b,
+// based on the descriptor of the message m. Since map entries and google.protobuf.Any payload fields can not be
+// enumerated, paths to map and Any fields that have some of their subpaths covered by b are removed. The special
+// value "*" in a is expanded in the same manner, and the special value "*" in b covers all paths.
+//
+// The result is normalized, see Normalize.
+func Subtract(a, b *fieldmaskpb.FieldMask, m proto.Message, opts ...SubtractOption) *fieldmaskpb.FieldMask {
+	options := subtractOptions{
+		resolver: protoregistry.GlobalTypes,
+	}
+	for _, opt := range opts {
+		opt.applySubtractOption(&options)
+	}
+	if stringsContain(WildcardPath, b.GetPaths()) {
+		return &fieldmaskpb.FieldMask{}
+	}
+	bPaths := parseMaskPaths(b)
+	var paths []string
+	if stringsContain(WildcardPath, a.GetPaths()) {
+		if len(bPaths) == 0 {
+			return &fieldmaskpb.FieldMask{Paths: []string{WildcardPath}}
+		}
+		paths = subtractFields(m.ProtoReflect().Descriptor(), nil, bPaths, paths)
+		return Normalize(&fieldmaskpb.FieldMask{Paths: paths})
+	}
+	for _, path := range parseMaskPaths(a) {
+		switch {
+		case isCovered(path, bPaths):
+			continue
+		case !hasDescendant(path, bPaths):
+			paths = append(paths, path.text)
+			continue
+		}
+		resolved, ok := resolvePath(m.ProtoReflect(), path.segments, options.resolver)
+		switch {
+		case !ok:
+			// unresolvable paths can not be expanded
+			paths = append(paths, path.text)
+		case resolved.isMap || isAny(resolved.message):
+			// map entries and payload fields can not be enumerated
+		case resolved.message == nil:
+			// invalid subpaths of a scalar field
+			paths = append(paths, path.text)
+		default:
+			paths = subtractFields(resolved.message, path.segments, bPaths, paths)
+		}
+	}
+	return Normalize(&fieldmaskpb.FieldMask{Paths: paths})
+}
+
+// subtractFields appends the paths of the fields of the message at prefix that are not covered by b.
+func subtractFields(md protoreflect.MessageDescriptor, prefix []string, b []maskPath, paths []string) []string {
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		path := newMaskPath(append(slices.Clip(prefix), fieldPathName(field)))
+		switch {
+		case isCovered(path, b):
+			continue
+		case !hasDescendant(path, b):
+			paths = append(paths, path.text)
+		case field.IsMap() || isAny(field.Message()):
+			// map entries and payload fields can not be enumerated
+		case field.Message() != nil:
+			paths = subtractFields(field.Message(), path.segments, b, paths)
+		default:
+			// invalid subpaths of a scalar field
+			paths = append(paths, path.text)
+		}
+	}
+	return paths
+}
+
+// Normalize returns a field mask with the paths of the provided field mask, without paths covered by other paths
+// and duplicates, in sorted order.
+//
+// Paths are formatted with FormatPath, so that equivalent paths are equal. Invalid paths are left as is, and only
+// cover themselves. If the field mask contains the special value "*", the result is the special value "*".
+func Normalize(mask *fieldmaskpb.FieldMask) *fieldmaskpb.FieldMask {
+	if stringsContain(WildcardPath, mask.GetPaths()) {
+		return &fieldmaskpb.FieldMask{Paths: []string{WildcardPath}}
+	}
+	paths := parseMaskPaths(mask)
+	slices.SortFunc(paths, func(a, b maskPath) int {
+		return slices.Compare(a.segments, b.segments)
+	})
+	result := &fieldmaskpb.FieldMask{}
+	var previous []maskPath
+	for _, path := range paths {
+		// Parent paths are sorted before their subpaths.
+		if isCovered(path, previous) {
+			continue
+		}
+		previous = append(previous, path)
+		result.Paths = append(result.Paths, path.text)
+	}
+	return result
+}
+
+// Contains reports whether the path is covered by the field mask, either by being in the field mask or by being
+// a subpath of a path in the field mask. The special value "*" covers all paths.
+func Contains(mask *fieldmaskpb.FieldMask, path string) bool {
+	if stringsContain(WildcardPath, mask.GetPaths()) {
+		return true
+	}
+	return isCovered(parseMaskPath(path), parseMaskPaths(mask))
+}
+
+// maskPath is a parsed field mask path.
+type maskPath struct {
+	// segments of the path, or the unparsed path as a single segment if invalid.
+	segments []string
+	// text of the path, formatted with FormatPath if valid.
+	text string
+	// valid is true if the path is syntactically valid.
+	valid bool
+}
+
+func newMaskPath(segments []string) maskPath {
+	return maskPath{segments: segments, text: FormatPath(segments), valid: true}
+}
+
+func parseMaskPath(path string) maskPath {
+	segments, err := ParsePath(path)
+	if err != nil {
+		return maskPath{segments: []string{path}, text: path}
+	}
+	return newMaskPath(segments)
+}
+
+func parseMaskPaths(mask *fieldmaskpb.FieldMask) []maskPath {
+	result := make([]maskPath, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		result = append(result, parseMaskPath(path))
+	}
+	return result
+}
+
+// covers reports whether the path p covers the path q, by being equal to or a parent path of q.
+func (p maskPath) covers(q maskPath) bool {
+	if !p.valid || !q.valid {
+		return p.text == q.text
+	}
+	return len(p.segments) <= len(q.segments) && slices.Equal(p.segments, q.segments[:len(p.segments)])
+}
+
+func isCovered(path maskPath, paths []maskPath) bool {
+	for _, p := range paths {
+		if p.covers(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDescendant reports whether any of the paths is a strict subpath of the path.
+func hasDescendant(path maskPath, paths []maskPath) bool {
+	for _, p := range paths {
+		if len(p.segments) > len(path.segments) && path.covers(p) {
+			return true
+		}
+	}
+	return false
+}
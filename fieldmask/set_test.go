@@ -0,0 +1,394 @@
+package fieldmask
+
+import (
+	"testing"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		masks    [][]string
+		expected []string
+	}{
+		{
+			name:     "none",
+			expected: nil,
+		},
+		{
+			name:     "empty",
+			masks:    [][]string{{}, {}},
+			expected: nil,
+		},
+		{
+			name:     "single",
+			masks:    [][]string{{"title", "author"}},
+			expected: []string{"author", "title"},
+		},
+		{
+			name:     "disjoint",
+			masks:    [][]string{{"title"}, {"author"}},
+			expected: []string{"author", "title"},
+		},
+		{
+			name:     "overlapping",
+			masks:    [][]string{{"title", "author"}, {"author", "name"}},
+			expected: []string{"author", "name", "title"},
+		},
+		{
+			name:     "nested covered by parent",
+			masks:    [][]string{{"message.string", "message.message.int64"}, {"message"}},
+			expected: []string{"message"},
+		},
+		{
+			name:     "nested siblings",
+			masks:    [][]string{{"message.string"}, {"message.int64", "string"}},
+			expected: []string{"message.int64", "message.string", "string"},
+		},
+		{
+			name:     "map entries",
+			masks:    [][]string{{"map_string_string.`a.b`"}, {"map_string_string.a"}},
+			expected: []string{"map_string_string.a", "map_string_string.`a.b`"},
+		},
+		{
+			name:     "wildcard",
+			masks:    [][]string{{"title"}, {"*"}},
+			expected: []string{"*"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			masks := make([]*fieldmaskpb.FieldMask, 0, len(tt.masks))
+			for _, paths := range tt.masks {
+				masks = append(masks, &fieldmaskpb.FieldMask{Paths: paths})
+			}
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, Union(masks...), protocmp.Transform())
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		a        []string
+		b        []string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			a:        []string{"title"},
+			b:        nil,
+			expected: nil,
+		},
+		{
+			name:     "disjoint",
+			a:        []string{"title"},
+			b:        []string{"author"},
+			expected: nil,
+		},
+		{
+			name:     "overlapping",
+			a:        []string{"title", "author"},
+			b:        []string{"author", "name"},
+			expected: []string{"author"},
+		},
+		{
+			name:     "nested in a",
+			a:        []string{"message.string", "string"},
+			b:        []string{"message"},
+			expected: []string{"message.string"},
+		},
+		{
+			name:     "nested in b",
+			a:        []string{"message"},
+			b:        []string{"message.message.int64", "int64"},
+			expected: []string{"message.message.int64"},
+		},
+		{
+			name:     "map entries",
+			a:        []string{"map_string_string"},
+			b:        []string{"map_string_string.`example.com/owner`"},
+			expected: []string{"map_string_string.`example.com/owner`"},
+		},
+		{
+			name:     "quoted",
+			a:        []string{"`message`.string"},
+			b:        []string{"message.`string`"},
+			expected: []string{"message.string"},
+		},
+		{
+			name:     "wildcard in a",
+			a:        []string{"*"},
+			b:        []string{"title", "author"},
+			expected: []string{"author", "title"},
+		},
+		{
+			name:     "wildcard in b",
+			a:        []string{"title", "author"},
+			b:        []string{"*"},
+			expected: []string{"author", "title"},
+		},
+		{
+			name:     "wildcard in both",
+			a:        []string{"*"},
+			b:        []string{"*"},
+			expected: []string{"*"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Intersect(&fieldmaskpb.FieldMask{Paths: tt.a}, &fieldmaskpb.FieldMask{Paths: tt.b})
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, actual, protocmp.Transform())
+		})
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		a        []string
+		b        []string
+		message  proto.Message
+		expected []string
+	}{
+		{
+			name:     "empty",
+			a:        []string{"title", "author"},
+			b:        nil,
+			message:  &library.Book{},
+			expected: []string{"author", "title"},
+		},
+		{
+			name:     "disjoint",
+			a:        []string{"title"},
+			b:        []string{"author"},
+			message:  &library.Book{},
+			expected: []string{"title"},
+		},
+		{
+			name:     "overlapping",
+			a:        []string{"title", "author"},
+			b:        []string{"author", "name"},
+			message:  &library.Book{},
+			expected: []string{"title"},
+		},
+		{
+			name:     "nested covered",
+			a:        []string{"message.string", "string"},
+			b:        []string{"message"},
+			message:  &syntaxv1.Message{},
+			expected: []string{"string"},
+		},
+		{
+			name:     "wildcard in a",
+			a:        []string{"*"},
+			b:        []string{"name", "title"},
+			message:  &library.Book{},
+			expected: []string{"author", "read"},
+		},
+		{
+			name:     "wildcard in b",
+			a:        []string{"title"},
+			b:        []string{"*"},
+			message:  &library.Book{},
+			expected: nil,
+		},
+		{
+			name:     "wildcard minus empty",
+			a:        []string{"*"},
+			b:        nil,
+			message:  &library.Book{},
+			expected: []string{"*"},
+		},
+		{
+			name:    "nested expanded",
+			a:       []string{"book"},
+			b:       []string{"book.name"},
+			message: &library.UpdateBookRequest{},
+			expected: []string{
+				"book.author",
+				"book.read",
+				"book.title",
+			},
+		},
+		{
+			name:    "repeated expanded",
+			a:       []string{"books"},
+			b:       []string{"books.name", "books.read"},
+			message: &library.ListBooksResponse{},
+			expected: []string{
+				"books.author",
+				"books.title",
+			},
+		},
+		{
+			name:     "map entries removed",
+			a:        []string{"map_string_string", "string"},
+			b:        []string{"map_string_string.a"},
+			message:  &syntaxv1.Message{},
+			expected: []string{"string"},
+		},
+		{
+			name:     "map entry expanded",
+			a:        []string{"map_string_message.a"},
+			b:        []string{"map_string_message.a.message", "map_string_message.a.repeated_message"},
+			message:  &syntaxv1.Message{},
+			expected: subtractExpectedMapEntryPaths(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Subtract(&fieldmaskpb.FieldMask{Paths: tt.a}, &fieldmaskpb.FieldMask{Paths: tt.b}, tt.message)
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, actual, protocmp.Transform())
+			assert.NilError(t, Validate(actual, tt.message))
+		})
+	}
+}
+
+func subtractExpectedMapEntryPaths() []string {
+	var result []string
+	fields := (&syntaxv1.Message{}).ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		switch name := string(fields.Get(i).Name()); name {
+		case "message", "repeated_message":
+		default:
+			result = append(result, "map_string_message.a."+name)
+		}
+	}
+	return Normalize(&fieldmaskpb.FieldMask{Paths: result}).GetPaths()
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		paths    []string
+		expected []string
+	}{
+		{
+			name:     "nil",
+			paths:    nil,
+			expected: nil,
+		},
+		{
+			name:     "sorted",
+			paths:    []string{"title", "author", "name"},
+			expected: []string{"author", "name", "title"},
+		},
+		{
+			name:     "duplicates",
+			paths:    []string{"title", "title", "`title`"},
+			expected: []string{"title"},
+		},
+		{
+			name:     "covered by parent",
+			paths:    []string{"message.message.string", "message.int64", "message", "messages"},
+			expected: []string{"message", "messages"},
+		},
+		{
+			name:     "parent sorted before sibling with common prefix",
+			paths:    []string{"message_a", "message.b", "message"},
+			expected: []string{"message", "message_a"},
+		},
+		{
+			name:     "quoted formatted",
+			paths:    []string{"map_string_string.`a`", "map_string_string.`a.b`"},
+			expected: []string{"map_string_string.a", "map_string_string.`a.b`"},
+		},
+		{
+			name:     "invalid kept",
+			paths:    []string{"title", "title..foo", "title."},
+			expected: []string{"title", "title.", "title..foo"},
+		},
+		{
+			name:     "wildcard",
+			paths:    []string{"title", "*"},
+			expected: []string{"*"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Normalize(&fieldmaskpb.FieldMask{Paths: tt.paths})
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, actual, protocmp.Transform())
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		paths    []string
+		path     string
+		expected bool
+	}{
+		{
+			name:     "empty",
+			paths:    nil,
+			path:     "title",
+			expected: false,
+		},
+		{
+			name:     "equal",
+			paths:    []string{"author", "title"},
+			path:     "title",
+			expected: true,
+		},
+		{
+			name:     "not contained",
+			paths:    []string{"author", "title"},
+			path:     "name",
+			expected: false,
+		},
+		{
+			name:     "nested",
+			paths:    []string{"message"},
+			path:     "message.message.string",
+			expected: true,
+		},
+		{
+			name:     "parent not contained by nested",
+			paths:    []string{"message.string"},
+			path:     "message",
+			expected: false,
+		},
+		{
+			name:     "common prefix",
+			paths:    []string{"message"},
+			path:     "message_a",
+			expected: false,
+		},
+		{
+			name:     "map entry",
+			paths:    []string{"map_string_string"},
+			path:     "map_string_string.`example.com/owner`",
+			expected: true,
+		},
+		{
+			name:     "quoted",
+			paths:    []string{"`message`.string"},
+			path:     "message.`string`",
+			expected: true,
+		},
+		{
+			name:     "wildcard",
+			paths:    []string{"*"},
+			path:     "message.string",
+			expected: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, Contains(&fieldmaskpb.FieldMask{Paths: tt.paths}, tt.path))
+		})
+	}
+}
@@ -2,24 +2,115 @@ package fieldmask
 
 import (
 	"fmt"
-	"strings"
+	"slices"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // Update updates fields in dst with values from src according to the provided field mask.
 // Nested messages are recursively updated in the same manner.
 // Repeated fields and maps are copied by reference from src to dst.
-// Field mask paths referring to Individual entries in maps or
-// repeated fields are ignored.
+// See UpdateWithOptions for deep copies and merging of repeated fields.
+//
+// Field mask paths may refer to individual map entries by key, such as labels.env, which sets the entry to the value
+// in src, or deletes it from dst when src has no entry with that key. Paths into map values, such as
+// map_string_message.key.string, update the fields of the value. Paths into elements of repeated fields are ignored.
+//
+// Field mask paths referring to a oneof member, or to fields of a member, select that member in dst, which clears the
+// other members of the oneof. A path to a member that is not set in src clears that member in dst.
+//
+// Field mask paths referring to fields of google.protobuf.Any fields, such as details.title, update the fields of the
+// payload. A payload in dst of another type than in src is replaced with an empty payload of the type in src first.
 //
 // If no update mask is provided, only non-zero values of src are copied to dst.
 // If the special value "*" is provided as the field mask, a full replacement of all fields in dst is done.
 //
 // See: https://google.aip.dev/134 (Standard methods: Update).
 func Update(mask *fieldmaskpb.FieldMask, dst, src proto.Message) {
+	UpdateWithOptions(mask, dst, src)
+}
+
+// UpdateOption configures UpdateWithOptions. See also WithTypeResolver.
+type UpdateOption interface {
+	applyUpdateOption(*updateOptions)
+}
+
+type updateOptionFunc func(*updateOptions)
+
+func (f updateOptionFunc) applyUpdateOption(opts *updateOptions) {
+	f(opts)
+}
+
+type updateOptions struct {
+	deepCopy          bool
+	listMergeStrategy ListMergeStrategy
+	listMergeKeys     map[string]protoreflect.Name
+	resolver          protoregistry.MessageTypeResolver
+}
+
+// ListMergeStrategy is a strategy for updating repeated fields.
+type ListMergeStrategy int
+
+const (
+	// ListMergeReplace replaces the elements of the repeated field in dst with the elements in src.
+	ListMergeReplace ListMergeStrategy = iota
+	// ListMergeAppend appends the elements in src to the elements of the repeated field in dst.
+	ListMergeAppend
+	// ListMergeByKey replaces the elements in dst that have the same key as an element in src, and appends the
+	// remaining elements in src. Message elements are keyed by a field, see WithListMergeKey, and scalar elements by
+	// their value. Message elements in src where the key field is not set, such as an empty string, are appended.
+	// Repeated message fields without a key field are replaced, as with ListMergeReplace.
+	ListMergeByKey
+)
+
+// WithDeepCopy makes UpdateWithOptions copy messages, repeated fields, maps and bytes from src to dst,
+// instead of copying them by reference, so that dst does not share memory with src.
+func WithDeepCopy() UpdateOption {
+	return updateOptionFunc(func(opts *updateOptions) {
+		opts.deepCopy = true
+	})
+}
+
+// WithListMergeStrategy sets the strategy for updating repeated fields. The default is ListMergeReplace.
+func WithListMergeStrategy(strategy ListMergeStrategy) UpdateOption {
+	return updateOptionFunc(func(opts *updateOptions) {
+		opts.listMergeStrategy = strategy
+	})
+}
+
+// WithListMergeKey sets the name of the key field of the message elements of the repeated field at the path, such as
+// WithListMergeKey("line_items", "external_reference_id"), for ListMergeByKey. Paths into map values include the
+// map key. Keys that are not singular fields of the elements are ignored.
+func WithListMergeKey(path, key string) UpdateOption {
+	return updateOptionFunc(func(opts *updateOptions) {
+		if segments, err := ParsePath(path); err == nil {
+			path = FormatPath(segments)
+		}
+		if opts.listMergeKeys == nil {
+			opts.listMergeKeys = map[string]protoreflect.Name{}
+		}
+		opts.listMergeKeys[path] = protoreflect.Name(key)
+	})
+}
+
+// UpdateWithOptions updates fields in dst with values from src according to the provided field mask,
+// in the same manner as Update, with options for how values are copied and how repeated fields are updated.
+//
+// The list merge strategy applies to repeated fields named by a path in the field mask, and to repeated fields set
+// in src when no field mask is provided. A full replacement with the special value "*" always replaces
+// repeated fields, and always copies values from src.
+//
+// See: https://google.aip.dev/134 (Standard methods: Update).
+func UpdateWithOptions(mask *fieldmaskpb.FieldMask, dst, src proto.Message, opts ...UpdateOption) {
+	options := updateOptions{
+		resolver: protoregistry.GlobalTypes,
+	}
+	for _, opt := range opts {
+		opt.applyUpdateOption(&options)
+	}
 	dstReflect := dst.ProtoReflect()
 	srcReflect := src.ProtoReflect()
 	if dstReflect.Descriptor() != srcReflect.Descriptor() {
@@ -33,7 +124,7 @@ func Update(mask *fieldmaskpb.FieldMask, dst, src proto.Message) {
 	// Special-case: No update mask.
 	// Update all fields of src that are set on the wire.
 	case len(mask.GetPaths()) == 0:
-		updateWireSetFields(dstReflect, srcReflect)
+		options.updateWireSetFields(dstReflect, srcReflect, nil)
 	// Special-case: Update mask is [*].
 	// Do a full replacement of all fields.
 	case IsFullReplacement(mask):
@@ -41,34 +132,44 @@ func Update(mask *fieldmaskpb.FieldMask, dst, src proto.Message) {
 		proto.Merge(dst, src)
 	default:
 		for _, path := range mask.GetPaths() {
-			segments := strings.Split(path, ".")
-			updateNamedField(dstReflect, srcReflect, segments)
+			segments, err := ParsePath(path)
+			if err != nil {
+				// invalid path syntax
+				continue
+			}
+			options.updateNamedField(dstReflect, srcReflect, nil, segments)
 		}
 	}
 }
 
-func updateWireSetFields(dst, src protoreflect.Message) {
+func (o *updateOptions) updateWireSetFields(dst, src protoreflect.Message, path []string) {
 	src.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fieldPath := append(slices.Clip(path), string(field.Name()))
 		switch {
 		case field.IsList():
-			dst.Set(field, value)
+			o.updateList(dst, field, value.List(), fieldPath)
 		case field.IsMap():
-			dst.Set(field, value)
+			dst.Set(field, o.copyValue(dst, field, value))
 		case field.Message() != nil && !dst.Has(field):
-			dst.Set(field, value)
+			dst.Set(field, o.copyValue(dst, field, value))
 		case field.Message() != nil:
-			updateWireSetFields(dst.Get(field).Message(), value.Message())
+			o.updateWireSetFields(dst.Get(field).Message(), value.Message(), fieldPath)
 		default:
-			dst.Set(field, value)
+			dst.Set(field, o.copyValue(dst, field, value))
 		}
 		return true
 	})
 }
 
-func updateNamedField(dst, src protoreflect.Message, segments []string) {
+// updateNamedField updates the field named by the segments, where path is the path of dst and src in the field mask.
+func (o *updateOptions) updateNamedField(dst, src protoreflect.Message, path, segments []string) {
 	if len(segments) == 0 {
 		return
 	}
+	if isAny(src.Descriptor()) {
+		o.updateAnyPayload(dst, src, path, segments)
+		return
+	}
 	field := src.Descriptor().Fields().ByName(protoreflect.Name(segments[0]))
 	if field == nil {
 		// no known field by that name
@@ -76,29 +177,251 @@ func updateNamedField(dst, src protoreflect.Message, segments []string) {
 	}
 	// a named field in this message
 	if len(segments) == 1 {
-		if !src.Has(field) {
+		switch {
+		case field.IsList() && o.listMergeStrategy != ListMergeReplace:
+			o.updateList(dst, field, src.Get(field).List(), append(path, segments[0]))
+		case !src.Has(field):
 			dst.Clear(field)
-		} else {
-			dst.Set(field, src.Get(field))
+		default:
+			dst.Set(field, o.copyValue(dst, field, src.Get(field)))
 		}
 		return
 	}
 
 	// a named field in a nested message
 	switch {
-	case field.IsList(), field.IsMap():
-		// nested fields in repeated or map not supported
+	case field.IsMap():
+		o.updateMapEntry(dst, src, field, append(path, segments[0]), segments[1:])
+	case field.IsList():
+		// nested fields in repeated not supported
 		return
 	case field.Message() != nil:
+		if isAny(field.Message()) && !dst.Has(field) && !src.Has(field) {
+			// no payload to update
+			return
+		}
 		// if message field is not set, allocate an empty value
 		if !dst.Has(field) {
 			dst.Set(field, dst.NewField(field))
 		}
-		if !src.Has(field) {
-			src.Set(field, src.NewField(field))
+		// an unset message field in src is an empty read-only message
+		o.updateNamedField(dst.Get(field).Message(), src.Get(field).Message(), append(path, segments[0]), segments[1:])
+	default:
+		return
+	}
+}
+
+func (o *updateOptions) updateMapEntry(
+	dst, src protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	path, segments []string,
+) {
+	key, ok := parseMapKey(field.MapKey(), segments[0])
+	if !ok {
+		// invalid map key
+		return
+	}
+	srcMap := src.Get(field).Map()
+	// a map entry
+	if len(segments) == 1 {
+		if !srcMap.Has(key) {
+			if dst.Has(field) {
+				dst.Mutable(field).Map().Clear(key)
+			}
+		} else {
+			dst.Mutable(field).Map().Set(key, o.copyElement(field.MapValue(), srcMap.Get(key)))
+		}
+		return
+	}
+	// a named field in a map message value
+	if field.MapValue().Message() == nil {
+		return
+	}
+	if !srcMap.Has(key) && !dst.Get(field).Map().Has(key) {
+		// no entry to update
+		return
+	}
+	dstMap := dst.Mutable(field).Map()
+	if !dstMap.Has(key) {
+		dstMap.Set(key, dstMap.NewValue())
+	}
+	srcValue := srcMap.Get(key)
+	if !srcMap.Has(key) {
+		srcValue = dstMap.NewValue()
+	}
+	o.updateNamedField(dstMap.Get(key).Message(), srcValue.Message(), append(path, segments[0]), segments[1:])
+}
+
+// updateAnyPayload updates the fields of the payload of the google.protobuf.Any message in dst with values from the
+// payload in src. The payload in dst is replaced with an empty payload of the type in src, when of a different type.
+func (o *updateOptions) updateAnyPayload(dst, src protoreflect.Message, path, segments []string) {
+	srcTypeURL, dstTypeURL := anyTypeURL(src), anyTypeURL(dst)
+	var srcPayload, dstPayload protoreflect.Message
+	var err error
+	switch {
+	case srcTypeURL != "":
+		if srcPayload, err = unpackAny(src, o.resolver); err != nil {
+			return
+		}
+		if dstTypeURL != srcTypeURL {
+			dstPayload = srcPayload.Type().New()
+		} else if dstPayload, err = unpackAny(dst, o.resolver); err != nil {
+			return
+		}
+	case dstTypeURL != "":
+		if dstPayload, err = unpackAny(dst, o.resolver); err != nil {
+			return
 		}
-		updateNamedField(dst.Get(field).Message(), src.Get(field).Message(), segments[1:])
+		srcPayload = dstPayload.Type().New()
 	default:
 		return
 	}
+	o.updateNamedField(dstPayload, srcPayload, path, segments)
+	typeURL := srcTypeURL
+	if typeURL == "" {
+		typeURL = dstTypeURL
+	}
+	_ = packAny(dst, typeURL, dstPayload) // dst is left unchanged on error
+}
+
+// updateList updates the repeated field in dst with the elements in src, according to the list merge strategy.
+// The path is the path of the repeated field in the field mask.
+func (o *updateOptions) updateList(
+	dst protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	src protoreflect.List,
+	path []string,
+) {
+	switch o.listMergeStrategy {
+	case ListMergeAppend:
+		if src.Len() == 0 {
+			return
+		}
+		dstList := dst.Mutable(field).List()
+		for i := 0; i < src.Len(); i++ {
+			dstList.Append(o.copyElement(field, src.Get(i)))
+		}
+	case ListMergeByKey:
+		keyField, ok := o.listMergeKeyField(field, path)
+		if !ok {
+			o.replaceList(dst, field, src)
+			return
+		}
+		if src.Len() == 0 {
+			return
+		}
+		dstList := dst.Mutable(field).List()
+		for i := 0; i < src.Len(); i++ {
+			element := src.Get(i)
+			if j := IndexByKey(dstList, keyField, element); j >= 0 {
+				dstList.Set(j, o.copyElement(field, element))
+			} else {
+				dstList.Append(o.copyElement(field, element))
+			}
+		}
+	default:
+		o.replaceList(dst, field, src)
+	}
+}
+
+// replaceList replaces the repeated field in dst with the elements in src.
+func (o *updateOptions) replaceList(
+	dst protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	src protoreflect.List,
+) {
+	if src.Len() == 0 {
+		dst.Clear(field)
+		return
+	}
+	dst.Set(field, o.copyValue(dst, field, protoreflect.ValueOfList(src)))
+}
+
+// listMergeKeyField returns the key field of the elements of the repeated field at the path, which is nil for scalar
+// elements, and false when message elements have no key field.
+func (o *updateOptions) listMergeKeyField(
+	field protoreflect.FieldDescriptor,
+	path []string,
+) (protoreflect.FieldDescriptor, bool) {
+	if field.Message() == nil {
+		return nil, true
+	}
+	key, ok := o.listMergeKeys[FormatPath(path)]
+	if !ok {
+		return nil, false
+	}
+	keyField := field.Message().Fields().ByName(key)
+	if keyField == nil || keyField.Cardinality() == protoreflect.Repeated {
+		return nil, false
+	}
+	return keyField, true
+}
+
+// IndexByKey returns the index of the element in the list with the same key as the provided element, or -1.
+// Message elements are keyed by the key field, and elements without the key field set never match. Scalar elements
+// are keyed by their value, when the key field is nil. See ListMergeByKey.
+func IndexByKey(list protoreflect.List, keyField protoreflect.FieldDescriptor, element protoreflect.Value) int {
+	if keyField == nil {
+		for i := 0; i < list.Len(); i++ {
+			if list.Get(i).Equal(element) {
+				return i
+			}
+		}
+		return -1
+	}
+	if !element.Message().Has(keyField) {
+		return -1
+	}
+	key := element.Message().Get(keyField)
+	for i := 0; i < list.Len(); i++ {
+		if list.Get(i).Message().Get(keyField).Equal(key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// copyValue returns a copy of the value of the field in src, for setting it in dst.
+// The value is only copied by reference unless deep copies are enabled.
+func (o *updateOptions) copyValue(
+	dst protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+) protoreflect.Value {
+	if !o.deepCopy {
+		return value
+	}
+	switch {
+	case field.IsList():
+		result := dst.NewField(field)
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			result.List().Append(o.copyElement(field, list.Get(i)))
+		}
+		return result
+	case field.IsMap():
+		result := dst.NewField(field)
+		value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			result.Map().Set(key, o.copyElement(field.MapValue(), value))
+			return true
+		})
+		return result
+	default:
+		return o.copyElement(field, value)
+	}
+}
+
+// copyElement returns a copy of a singular value, list element or map value of the field.
+func (o *updateOptions) copyElement(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+	if !o.deepCopy {
+		return value
+	}
+	switch {
+	case field.Message() != nil:
+		return protoreflect.ValueOfMessage(proto.Clone(value.Message().Interface()).ProtoReflect())
+	case field.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(append([]byte(nil), value.Bytes()...))
+	default:
+		return value
+	}
 }
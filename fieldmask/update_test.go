@@ -3,6 +3,7 @@ package fieldmask
 import (
 	"testing"
 
+	examplefreightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
 	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/protobuf/proto"
@@ -599,6 +600,117 @@ func TestUpdate(t *testing.T) {
 				expected: &syntaxv1.Message{
 					MapStringString: map[string]string{
 						"dst-key": "dst-value",
+						"src1":    "src1-value",
+					},
+				},
+			},
+			{
+				name: "maps: deep delete",
+				paths: []string{
+					"map_string_string.dst-key",
+				},
+				src: &syntaxv1.Message{
+					MapStringString: map[string]string{
+						"src1": "src1-value",
+					},
+				},
+				dst: &syntaxv1.Message{
+					MapStringString: map[string]string{
+						"dst-key":  "dst-value",
+						"dst-key2": "dst-value2",
+					},
+				},
+				expected: &syntaxv1.Message{
+					MapStringString: map[string]string{
+						"dst-key2": "dst-value2",
+					},
+				},
+			},
+			{
+				name: "maps: deep quoted key",
+				paths: []string{
+					"map_string_string.`example.com/owner`",
+				},
+				src: &syntaxv1.Message{
+					MapStringString: map[string]string{
+						"example.com/owner": "src-value",
+					},
+				},
+				dst: &syntaxv1.Message{},
+				expected: &syntaxv1.Message{
+					MapStringString: map[string]string{
+						"example.com/owner": "src-value",
+					},
+				},
+			},
+			{
+				name: "maps: deep message value",
+				paths: []string{
+					"map_string_message.key.string",
+				},
+				src: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key": {String_: "src-value", Int64: 1},
+					},
+				},
+				dst: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key":     {String_: "dst-value", Int64: 2},
+						"dst-key": {String_: "dst-value"},
+					},
+				},
+				expected: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key":     {String_: "src-value", Int64: 2},
+						"dst-key": {String_: "dst-value"},
+					},
+				},
+			},
+			{
+				name: "maps: deep message value missing in dst",
+				paths: []string{
+					"map_string_message.key.string",
+				},
+				src: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key": {String_: "src-value", Int64: 1},
+					},
+				},
+				dst: &syntaxv1.Message{},
+				expected: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key": {String_: "src-value"},
+					},
+				},
+			},
+			{
+				name: "maps: deep message value missing in dst and src",
+				paths: []string{
+					"map_string_message.key.string",
+				},
+				src:      &syntaxv1.Message{},
+				dst:      &syntaxv1.Message{},
+				expected: &syntaxv1.Message{},
+			},
+			{
+				name: "maps: map entry field name is a map key",
+				paths: []string{
+					"map_string_message.message.string",
+				},
+				src: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"message": {String_: "src-value"},
+					},
+				},
+				dst: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key": {String_: "dst-value"},
+					},
+				},
+				expected: &syntaxv1.Message{
+					MapStringMessage: map[string]*syntaxv1.Message{
+						"key":     {String_: "dst-value"},
+						"message": {String_: "src-value"},
 					},
 				},
 			},
@@ -714,6 +826,23 @@ func TestUpdate(t *testing.T) {
 					Oneof: nil,
 				},
 			},
+			{
+				name: "oneof: src nil other member kept",
+				paths: []string{
+					"oneof_message2",
+				},
+				src: &syntaxv1.Message{},
+				dst: &syntaxv1.Message{
+					Oneof: &syntaxv1.Message_OneofString{
+						OneofString: "dst",
+					},
+				},
+				expected: &syntaxv1.Message{
+					Oneof: &syntaxv1.Message_OneofString{
+						OneofString: "dst",
+					},
+				},
+			},
 			{
 				name: "oneof: deep",
 				paths: []string{
@@ -766,6 +895,30 @@ func TestUpdate(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "oneof: deep member unset in dst and src",
+				paths: []string{
+					"oneof_message1.string",
+				},
+				src: &syntaxv1.Message{
+					Oneof: &syntaxv1.Message_OneofMessage2{
+						OneofMessage2: &syntaxv1.Message{
+							String_: "src",
+						},
+					},
+				},
+				dst: &syntaxv1.Message{
+					Oneof: &syntaxv1.Message_OneofString{
+						OneofString: "dst",
+					},
+				},
+				// The member is selected with the named fields set to the values in src, clearing the member of dst.
+				expected: &syntaxv1.Message{
+					Oneof: &syntaxv1.Message_OneofMessage1{
+						OneofMessage1: &syntaxv1.Message{},
+					},
+				},
+			},
 			{
 				name: "message: src nil",
 				paths: []string{
@@ -792,3 +945,298 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateWithOptions(t *testing.T) {
+	t.Parallel()
+	t.Run("list merge strategies", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			name     string
+			paths    []string
+			opts     []UpdateOption
+			src      proto.Message
+			dst      proto.Message
+			expected proto.Message
+		}{
+			{
+				name:  "replace",
+				paths: []string{"line_items"},
+				src: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "src", Quantity: 1}},
+				},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst", Quantity: 2}},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "src", Quantity: 1}},
+				},
+			},
+			{
+				name:  "replace with empty",
+				paths: []string{"line_items"},
+				src:   &examplefreightv1.Shipment{},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst", Quantity: 2}},
+				},
+				expected: &examplefreightv1.Shipment{},
+			},
+			{
+				name:  "append",
+				paths: []string{"line_items"},
+				opts:  []UpdateOption{WithListMergeStrategy(ListMergeAppend)},
+				src: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "src", Quantity: 1}},
+				},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst", Quantity: 2}},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{
+						{Title: "dst", Quantity: 2},
+						{Title: "src", Quantity: 1},
+					},
+				},
+			},
+			{
+				name:  "append empty",
+				paths: []string{"line_items"},
+				opts:  []UpdateOption{WithListMergeStrategy(ListMergeAppend)},
+				src:   &examplefreightv1.Shipment{},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst", Quantity: 2}},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst", Quantity: 2}},
+				},
+			},
+			{
+				name:  "merge by key",
+				paths: []string{"line_items"},
+				opts: []UpdateOption{
+					WithListMergeStrategy(ListMergeByKey),
+					WithListMergeKey("line_items", "external_reference_id"),
+				},
+				src: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{
+						{ExternalReferenceId: "b", Title: "src-b"},
+						{ExternalReferenceId: "c", Title: "src-c"},
+					},
+				},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{
+						{ExternalReferenceId: "a", Title: "dst-a"},
+						{ExternalReferenceId: "b", Title: "dst-b", Quantity: 2},
+					},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{
+						{ExternalReferenceId: "a", Title: "dst-a"},
+						{ExternalReferenceId: "b", Title: "src-b"},
+						{ExternalReferenceId: "c", Title: "src-c"},
+					},
+				},
+			},
+			{
+				name:  "merge by key without key",
+				paths: []string{"line_items"},
+				opts: []UpdateOption{
+					WithListMergeStrategy(ListMergeByKey),
+					WithListMergeKey("line_items", "external_reference_id"),
+				},
+				src: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "src"}},
+				},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst"}},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst"}, {Title: "src"}},
+				},
+			},
+			{
+				name:  "merge by key without key field replaces",
+				paths: []string{"line_items"},
+				opts:  []UpdateOption{WithListMergeStrategy(ListMergeByKey)},
+				src: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "src"}},
+				},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "dst"}},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{Title: "src"}},
+				},
+			},
+			{
+				name:  "merge by key with unknown key field replaces",
+				paths: []string{"line_items"},
+				opts: []UpdateOption{
+					WithListMergeStrategy(ListMergeByKey),
+					WithListMergeKey("line_items", "external_reference"),
+				},
+				src: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{ExternalReferenceId: "a", Title: "src"}},
+				},
+				dst: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{ExternalReferenceId: "b", Title: "dst"}},
+				},
+				expected: &examplefreightv1.Shipment{
+					LineItems: []*examplefreightv1.LineItem{{ExternalReferenceId: "a", Title: "src"}},
+				},
+			},
+			{
+				name:  "merge by key of nested field",
+				paths: []string{"message_without_field_behavior.repeated_message", "map_message.key1.repeated_message"},
+				opts: []UpdateOption{
+					WithListMergeStrategy(ListMergeByKey),
+					WithListMergeKey("message_without_field_behavior.repeated_message", "field"),
+					WithListMergeKey("map_message.`key1`.repeated_message", "field"),
+				},
+				src: &syntaxv1.FieldBehaviorMessage{
+					MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+						RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a", OptionalField: "src"}},
+					},
+					MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+						"key1": {RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a", OptionalField: "src"}}},
+					},
+				},
+				dst: &syntaxv1.FieldBehaviorMessage{
+					MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+						RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a"}, {Field: "b"}},
+					},
+					MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+						"key1": {RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a"}, {Field: "b"}}},
+					},
+				},
+				expected: &syntaxv1.FieldBehaviorMessage{
+					MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+						RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a", OptionalField: "src"}, {Field: "b"}},
+					},
+					MapMessage: map[string]*syntaxv1.FieldBehaviorMessage{
+						"key1": {RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a", OptionalField: "src"}, {Field: "b"}}},
+					},
+				},
+			},
+			{
+				name: "merge by key of nested wire set field",
+				opts: []UpdateOption{
+					WithListMergeStrategy(ListMergeByKey),
+					WithListMergeKey("message_without_field_behavior.repeated_message", "field"),
+				},
+				src: &syntaxv1.FieldBehaviorMessage{
+					MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+						RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a", OptionalField: "src"}},
+					},
+				},
+				dst: &syntaxv1.FieldBehaviorMessage{
+					MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+						RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a"}, {Field: "b"}},
+					},
+				},
+				expected: &syntaxv1.FieldBehaviorMessage{
+					MessageWithoutFieldBehavior: &syntaxv1.FieldBehaviorMessage{
+						RepeatedMessage: []*syntaxv1.FieldBehaviorMessage{{Field: "a", OptionalField: "src"}, {Field: "b"}},
+					},
+				},
+			},
+			{
+				name:  "merge scalars by value",
+				paths: []string{"repeated_string"},
+				opts:  []UpdateOption{WithListMergeStrategy(ListMergeByKey)},
+				src: &syntaxv1.Message{
+					RepeatedString: []string{"b", "c"},
+				},
+				dst: &syntaxv1.Message{
+					RepeatedString: []string{"a", "b"},
+				},
+				expected: &syntaxv1.Message{
+					RepeatedString: []string{"a", "b", "c"},
+				},
+			},
+			{
+				name: "append wire set fields",
+				opts: []UpdateOption{WithListMergeStrategy(ListMergeAppend)},
+				src: &syntaxv1.Message{
+					String_:        "src",
+					RepeatedString: []string{"b"},
+				},
+				dst: &syntaxv1.Message{
+					RepeatedString: []string{"a"},
+				},
+				expected: &syntaxv1.Message{
+					String_:        "src",
+					RepeatedString: []string{"a", "b"},
+				},
+			},
+			{
+				name:  "full replacement ignores strategy",
+				paths: []string{"*"},
+				opts:  []UpdateOption{WithListMergeStrategy(ListMergeAppend)},
+				src: &syntaxv1.Message{
+					RepeatedString: []string{"b"},
+				},
+				dst: &syntaxv1.Message{
+					RepeatedString: []string{"a"},
+				},
+				expected: &syntaxv1.Message{
+					RepeatedString: []string{"b"},
+				},
+			},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				var mask *fieldmaskpb.FieldMask
+				if tt.paths != nil {
+					mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+				}
+				UpdateWithOptions(mask, tt.dst, tt.src, tt.opts...)
+				assert.DeepEqual(t, tt.expected, tt.dst, protocmp.Transform())
+			})
+		}
+	})
+
+	t.Run("deep copy", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			name  string
+			paths []string
+		}{
+			{name: "paths", paths: []string{"message", "repeated_message", "map_string_message", "bytes"}},
+			{name: "map entry paths", paths: []string{"message", "repeated_message", "map_string_message.a", "bytes"}},
+			{name: "wire set fields"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				src := &syntaxv1.Message{
+					Bytes:            []byte("src"),
+					Message:          &syntaxv1.Message{String_: "src"},
+					RepeatedMessage:  []*syntaxv1.Message{{String_: "src"}},
+					MapStringMessage: map[string]*syntaxv1.Message{"a": {String_: "src"}},
+				}
+				srcClone := proto.Clone(src)
+				dst := &syntaxv1.Message{}
+				var mask *fieldmaskpb.FieldMask
+				if tt.paths != nil {
+					mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+				}
+				UpdateWithOptions(mask, dst, src, WithDeepCopy())
+				assert.DeepEqual(t, srcClone, dst, protocmp.Transform())
+				// Mutating dst must not affect src.
+				dst.Bytes[0] = 'x'
+				dst.Message.String_ = "dst"
+				dst.RepeatedMessage[0].String_ = "dst"
+				dst.MapStringMessage["a"].String_ = "dst"
+				assert.DeepEqual(t, srcClone, src, protocmp.Transform())
+			})
+		}
+	})
+
+	t.Run("src not mutated", func(t *testing.T) {
+		t.Parallel()
+		src := &syntaxv1.Message{}
+		dst := &syntaxv1.Message{Message: &syntaxv1.Message{String_: "dst", Int64: 1}}
+		UpdateWithOptions(&fieldmaskpb.FieldMask{Paths: []string{"message.string"}}, dst, src)
+		assert.DeepEqual(t, &syntaxv1.Message{}, src, protocmp.Transform())
+		assert.DeepEqual(t, &syntaxv1.Message{Message: &syntaxv1.Message{Int64: 1}}, dst, protocmp.Transform())
+	})
+}
@@ -0,0 +1,117 @@
+package fieldmask
+
+import (
+	"fmt"
+
+	"go.einride.tech/aip/validation"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// UpdateMaskOption configures ValidateUpdateMask. See also WithTypeResolver.
+type UpdateMaskOption interface {
+	applyUpdateMaskOption(*updateMaskOptions)
+}
+
+type updateMaskOptionFunc func(*updateMaskOptions)
+
+func (f updateMaskOptionFunc) applyUpdateMaskOption(opts *updateMaskOptions) {
+	f(opts)
+}
+
+type updateMaskOptions struct {
+	rejectOutputOnly bool
+	resolver         protoregistry.MessageTypeResolver
+}
+
+// WithRejectOutputOnly makes ValidateUpdateMask reject paths to OUTPUT_ONLY fields,
+// instead of silently removing them from the update mask.
+func WithRejectOutputOnly() UpdateMaskOption {
+	return updateMaskOptionFunc(func(opts *updateMaskOptions) {
+		opts.rejectOutputOnly = true
+	})
+}
+
+// ValidateUpdateMask validates the update mask of an Update request for the provided resource,
+// and returns the update mask with the paths to update.
+//
+// Paths must be syntactically valid and refer to known fields of the resource, see Validate. Paths to IMMUTABLE and
+// IDENTIFIER fields, or into them, are rejected. Paths to OUTPUT_ONLY fields, or into them, are removed from the
+// returned update mask, unless WithRejectOutputOnly is provided. An update mask where all paths are removed is
+// rejected with a field violation for update_mask, since an empty update mask means a full update of all fields.
+//
+// The returned error is a validation error with one field violation per rejected path, for the field
+// update_mask.paths[i] of the request. See validation.Error.
+//
+// An update mask with no paths, or the special value "*", is returned unchanged. A full replacement includes all
+// fields, and should be combined with e.g. fieldbehavior.ValidateImmutableFieldsNotChanged and
+// fieldbehavior.ClearFields.
+//
+// See: https://google.aip.dev/134 (Standard methods: Update).
+// See: https://google.aip.dev/203 (Field behavior documentation).
+func ValidateUpdateMask(
+	mask *fieldmaskpb.FieldMask,
+	resource proto.Message,
+	opts ...UpdateMaskOption,
+) (*fieldmaskpb.FieldMask, error) {
+	options := updateMaskOptions{
+		resolver: protoregistry.GlobalTypes,
+	}
+	for _, opt := range opts {
+		opt.applyUpdateMaskOption(&options)
+	}
+	if len(mask.GetPaths()) == 0 || IsFullReplacement(mask) {
+		return mask, nil
+	}
+	result := &fieldmaskpb.FieldMask{Paths: make([]string, 0, len(mask.GetPaths()))}
+	var v validation.MessageValidator
+	for i, path := range mask.GetPaths() {
+		field := fmt.Sprintf("update_mask.paths[%d]", i)
+		segments, err := ParsePath(path)
+		if err != nil {
+			v.AddFieldViolation(field, "invalid field path: %s", path)
+			continue
+		}
+		resolved, ok := resolvePath(resource.ProtoReflect(), segments, options.resolver)
+		if !ok {
+			v.AddFieldViolation(field, "invalid field path: %s", path)
+			continue
+		}
+		switch {
+		case hasFieldBehavior(resolved.fields, annotations.FieldBehavior_IDENTIFIER):
+			v.AddFieldViolation(field, "field is an identifier: %s", path)
+		case hasFieldBehavior(resolved.fields, annotations.FieldBehavior_IMMUTABLE):
+			v.AddFieldViolation(field, "field is immutable: %s", path)
+		case hasFieldBehavior(resolved.fields, annotations.FieldBehavior_OUTPUT_ONLY):
+			if options.rejectOutputOnly {
+				v.AddFieldViolation(field, "field is output only: %s", path)
+			}
+		default:
+			result.Paths = append(result.Paths, path)
+		}
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if len(result.Paths) == 0 {
+		v.AddFieldViolation("update_mask", "no updatable fields in update mask")
+		return nil, v.Err()
+	}
+	return result, nil
+}
+
+// hasFieldBehavior returns true if any of the fields has the wanted field behavior.
+func hasFieldBehavior(fields []protoreflect.FieldDescriptor, want annotations.FieldBehavior) bool {
+	for _, field := range fields {
+		behaviors, _ := proto.GetExtension(field.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+		for _, got := range behaviors {
+			if got == want {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package fieldmask
+
+import (
+	"errors"
+	"testing"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"go.einride.tech/aip/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateUpdateMask(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name       string
+		paths      []string
+		resource   proto.Message
+		opts       []UpdateMaskOption
+		expected   []string
+		violations []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:     "empty",
+			paths:    []string{},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			expected: []string{},
+		},
+		{
+			name:     "wildcard",
+			paths:    []string{"*"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			expected: []string{"*"},
+		},
+		{
+			name:     "valid",
+			paths:    []string{"field", "optional_field", "message_without_field_behavior.field", "map_message.`a.b`"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			expected: []string{"field", "optional_field", "message_without_field_behavior.field", "map_message.`a.b`"},
+		},
+		{
+			name:     "output only removed",
+			paths:    []string{"field", "output_only_field", "output_only_message.field", "map_message.a.output_only_field"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			expected: []string{"field"},
+		},
+		{
+			name:     "only output only",
+			paths:    []string{"output_only_field", "output_only_message.field"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "update_mask", Description: "no updatable fields in update mask"},
+			},
+		},
+		{
+			name:     "output only rejected",
+			paths:    []string{"field", "output_only_field", "output_only_message.field"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			opts:     []UpdateMaskOption{WithRejectOutputOnly()},
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "update_mask.paths[1]", Description: "field is output only: output_only_field"},
+				{Field: "update_mask.paths[2]", Description: "field is output only: output_only_message.field"},
+			},
+		},
+		{
+			name:     "immutable",
+			paths:    []string{"field", "immutable_field", "message_without_field_behavior.immutable_field"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "update_mask.paths[1]", Description: "field is immutable: immutable_field"},
+				{
+					Field:       "update_mask.paths[2]",
+					Description: "field is immutable: message_without_field_behavior.immutable_field",
+				},
+			},
+		},
+		{
+			name:     "identifier",
+			paths:    []string{"name", "display_name"},
+			resource: &syntaxv1.IdentifierFieldBehaviorMessage{},
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "update_mask.paths[0]", Description: "field is an identifier: name"},
+			},
+		},
+		{
+			name:     "invalid",
+			paths:    []string{"field", "foo", "field..bar", "*"},
+			resource: &syntaxv1.FieldBehaviorMessage{},
+			violations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "update_mask.paths[1]", Description: "invalid field path: foo"},
+				{Field: "update_mask.paths[2]", Description: "invalid field path: field..bar"},
+				{Field: "update_mask.paths[3]", Description: "invalid field path: *"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ValidateUpdateMask(&fieldmaskpb.FieldMask{Paths: tt.paths}, tt.resource, tt.opts...)
+			if len(tt.violations) > 0 {
+				var errValidation *validation.Error
+				assert.Assert(t, errors.As(err, &errValidation))
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				var details []*errdetails.BadRequest_FieldViolation
+				for _, detail := range status.Convert(err).Details() {
+					if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+						details = append(details, badRequest.GetFieldViolations()...)
+					}
+				}
+				assert.DeepEqual(t, tt.violations, details, protocmp.Transform())
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, actual, protocmp.Transform())
+		})
+	}
+}
@@ -6,12 +6,42 @@ import (
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// ValidateOption configures Validate. See WithTypeResolver.
+type ValidateOption interface {
+	applyValidateOption(*validateOptions)
+}
+
+type validateOptions struct {
+	resolver protoregistry.MessageTypeResolver
+}
+
 // Validate validates that the paths in the provided field mask are syntactically valid and
 // refer to known fields in the specified message type.
-func Validate(fm *fieldmaskpb.FieldMask, m proto.Message) error {
+//
+// Paths into map fields refer to individual map entries by key, such as labels.env, and may continue into the fields
+// of message values. Map keys that are not valid identifiers must be quoted with backticks, such as
+// labels.`example.com/owner`. See: https://google.aip.dev/161 (Field masks).
+//
+// The segment after a map field is always a map key. For example, map_string_message.value.string refers to the
+// string field of the map value with key "value".
+//
+// Breaking change: earlier versions resolved the segment after a map field against the fields of the map value
+// message. A path such as map_string_message.string, which used to refer to the string field of every map value,
+// now refers to the map entry with key "string", and Update sets or deletes that entry.
+//
+// Paths into google.protobuf.Any fields, such as details.title, are only valid for singular Any fields that are set in
+// the message, with a known payload type.
+func Validate(fm *fieldmaskpb.FieldMask, m proto.Message, opts ...ValidateOption) error {
+	options := validateOptions{
+		resolver: protoregistry.GlobalTypes,
+	}
+	for _, opt := range opts {
+		opt.applyValidateOption(&options)
+	}
 	// special case for '*'
 	if stringsContain(WildcardPath, fm.GetPaths()) {
 		if len(fm.GetPaths()) != 1 {
@@ -19,63 +49,112 @@ func Validate(fm *fieldmaskpb.FieldMask, m proto.Message) error {
 		}
 		return nil
 	}
-	md0 := m.ProtoReflect().Descriptor()
 	for _, path := range fm.GetPaths() {
-		md := md0
-		if !rangeFields(path, func(field string) bool {
-			// Search the field within the message.
-			if md == nil {
-				return false // not within a message
+		if !isValidPath(m.ProtoReflect(), path, options.resolver) {
+			return fmt.Errorf("invalid field path: %s", path)
+		}
+	}
+	return nil
+}
+
+func isValidPath(m protoreflect.Message, path string, resolver protoregistry.MessageTypeResolver) bool {
+	segments, err := ParsePath(path)
+	if err != nil {
+		return false
+	}
+	_, ok := resolvePath(m, segments, resolver)
+	return ok
+}
+
+// resolvedPath is a field mask path resolved against a message.
+type resolvedPath struct {
+	// fields along the path, one for each segment that is not a map key.
+	fields []protoreflect.FieldDescriptor
+	// message at the end of the path, nil if the path does not refer to a message, the elements of a repeated message
+	// field or a map message value.
+	message protoreflect.MessageDescriptor
+	// isMap is true if the path refers to a map field, rather than one of its entries.
+	isMap bool
+}
+
+// resolvePath resolves the path segments against the message.
+//
+// Paths are resolved against the message descriptor, except for paths into google.protobuf.Any fields, which are
+// resolved against the payload type of the value in the message. Returns false if the path does not refer to a known
+// field. See Validate.
+func resolvePath(
+	m protoreflect.Message,
+	segments []string,
+	resolver protoregistry.MessageTypeResolver,
+) (resolvedPath, bool) {
+	result := resolvedPath{message: m.Descriptor()}
+	value := m // the value of the current message, or nil when not known
+	for i := 0; i < len(segments); i++ {
+		// Search the field within the message.
+		if result.message == nil {
+			return resolvedPath{}, false // not within a message
+		}
+		if isAny(result.message) {
+			// Search the field within the payload.
+			if value == nil {
+				return resolvedPath{}, false // payload type not known
 			}
-			fd := md.Fields().ByName(protoreflect.Name(field))
-			// The real field name of a group is the message name.
-			if fd == nil {
-				gd := md.Fields().ByName(protoreflect.Name(strings.ToLower(field)))
-				if gd != nil && gd.Kind() == protoreflect.GroupKind && string(gd.Message().Name()) == field {
-					fd = gd
-				}
-			} else if fd.Kind() == protoreflect.GroupKind && string(fd.Message().Name()) != field {
-				fd = nil
+			payload, err := unpackAny(value, resolver)
+			if err != nil {
+				return resolvedPath{}, false
 			}
-			if fd == nil {
-				return false // message has does not have this field
+			result.message, value = payload.Descriptor(), payload
+		}
+		fd := findField(result.message, segments[i])
+		if fd == nil {
+			return resolvedPath{}, false // message does not have this field
+		}
+		result.fields = append(result.fields, fd)
+		// Identify the next message to search within.
+		result.message = fd.Message() // may be nil
+		switch {
+		case fd.IsMap():
+			if i+1 == len(segments) {
+				result.message, result.isMap = nil, true
+				return result, true
 			}
-			// Identify the next message to search within.
-			md = fd.Message() // may be nil
-			if fd.IsMap() {
-				md = fd.MapValue().Message() // may be nil
+			// The next segment is a map key.
+			i++
+			if _, ok := parseMapKey(fd.MapKey(), segments[i]); !ok {
+				return resolvedPath{}, false
 			}
-			return true
-		}) {
-			return fmt.Errorf("invalid field path: %s", path)
+			result.message, value = fd.MapValue().Message(), nil // may be nil
+		case fd.IsList():
+			value = nil
+		case fd.Message() != nil && value != nil:
+			value = value.Get(fd).Message()
+		default:
+			value = nil
 		}
 	}
-	return nil
+	return result, true
 }
 
-func stringsContain(str string, ss []string) bool {
-	for _, s := range ss {
-		if s == str {
-			return true
+// findField returns the field with the provided name in the message, or nil if no such field exists.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fd := md.Fields().ByName(protoreflect.Name(name))
+	// The real field name of a group is the message name.
+	if fd == nil {
+		gd := md.Fields().ByName(protoreflect.Name(strings.ToLower(name)))
+		if gd != nil && gd.Kind() == protoreflect.GroupKind && string(gd.Message().Name()) == name {
+			fd = gd
 		}
+	} else if fd.Kind() == protoreflect.GroupKind && string(fd.Message().Name()) != name {
+		fd = nil
 	}
-	return false
+	return fd
 }
 
-func rangeFields(path string, f func(field string) bool) bool {
-	for {
-		var field string
-		if i := strings.IndexByte(path, '.'); i >= 0 {
-			field, path = path[:i], path[i:]
-		} else {
-			field, path = path, ""
-		}
-		if !f(field) {
-			return false
-		}
-		if len(path) == 0 {
+func stringsContain(str string, ss []string) bool {
+	for _, s := range ss {
+		if s == str {
 			return true
 		}
-		path = strings.TrimPrefix(path, ".")
 	}
+	return false
 }
@@ -3,6 +3,7 @@ package fieldmask
 import (
 	"testing"
 
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -71,6 +72,66 @@ func TestValidate(t *testing.T) {
 			message:       &library.CreateBookRequest{},
 			errorContains: "invalid field path: book.foo",
 		},
+
+		{
+			name: "valid map key",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"map_string_string.key", "map_string_message.key.string"},
+			},
+			message: &syntaxv1.Message{},
+		},
+
+		{
+			name: "map entry field names are map keys",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"map_string_message.message.string", "map_string_message.value.string"},
+			},
+			message: &syntaxv1.Message{},
+		},
+
+		{
+			name: "invalid map key",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"map_string_message.value.value"},
+			},
+			message:       &syntaxv1.Message{},
+			errorContains: "invalid field path: map_string_message.value.value",
+		},
+
+		{
+			name: "invalid nested wildcard",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"book.*"},
+			},
+			message:       &library.UpdateBookRequest{},
+			errorContains: "invalid field path: book.*",
+		},
+
+		{
+			name: "valid quoted map key",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"map_string_string.`example.com/owner`", "map_string_string.`a``b`"},
+			},
+			message: &syntaxv1.Message{},
+		},
+
+		{
+			name: "invalid map key",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"map_string_string.key.foo"},
+			},
+			message:       &syntaxv1.Message{},
+			errorContains: "invalid field path: map_string_string.key.foo",
+		},
+
+		{
+			name: "invalid unterminated quoted map key",
+			fieldMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"map_string_string.`key"},
+			},
+			message:       &syntaxv1.Message{},
+			errorContains: "invalid field path: map_string_string.`key",
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
@@ -1,6 +1,14 @@
 package fieldmask
 
-import "google.golang.org/protobuf/types/known/fieldmaskpb"
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
 
 // WildcardPath is the path used for update masks that should perform a full replacement.
 const WildcardPath = "*"
@@ -10,3 +18,120 @@ const WildcardPath = "*"
 func IsFullReplacement(fm *fieldmaskpb.FieldMask) bool {
 	return len(fm.GetPaths()) == 1 && fm.GetPaths()[0] == WildcardPath
 }
+
+// ExpandOption configures Expand. See also WithTypeResolver.
+type ExpandOption interface {
+	applyExpandOption(*expandOptions)
+}
+
+type expandOptionFunc func(*expandOptions)
+
+func (f expandOptionFunc) applyExpandOption(opts *expandOptions) {
+	f(opts)
+}
+
+type expandOptions struct {
+	maxDepth int
+	resolver protoregistry.MessageTypeResolver
+}
+
+// WithExpandMaxDepth limits the number of segments of the paths expanded from wildcards by Expand.
+//
+// Nested messages at the depth limit are not expanded further, and result in a single path to the message field.
+// Wildcards are always expanded into at least the fields of the message they refer to. The default is no limit.
+func WithExpandMaxDepth(depth int) ExpandOption {
+	return expandOptionFunc(func(opts *expandOptions) {
+		opts.maxDepth = depth
+	})
+}
+
+// Expand returns a field mask with the wildcard paths of the provided field mask expanded into explicit paths to the
+// leaf fields of the message m, based on its descriptor.
+//
+// The special value "*" expands into the leaf fields of m, and paths ending with a wildcard segment, such as
+// address.*, expand into the leaf fields of the nested message they refer to. Leaf fields are scalar fields, repeated
+// fields, map fields, message fields that are members of a oneof, and fields of the well-known types in the
+// google.protobuf package, such as google.protobuf.Timestamp. Recursive message fields are not expanded, and result in
+// a single path to the field.
+//
+// Wildcard path segments are only supported by Expand, and other functions of this package, such as Validate, Update
+// and Prune, treat them as field names. Field masks with paths such as address.* must be expanded before being used.
+//
+// Paths without wildcards are kept as is. Wildcard paths that do not refer to a nested message, such as paths to
+// scalar fields or unknown fields, are also kept as is, so the result should be validated. See Validate.
+//
+// The result is normalized, see Normalize.
+func Expand(mask *fieldmaskpb.FieldMask, m proto.Message, opts ...ExpandOption) *fieldmaskpb.FieldMask {
+	options := expandOptions{
+		resolver: protoregistry.GlobalTypes,
+	}
+	for _, opt := range opts {
+		opt.applyExpandOption(&options)
+	}
+	var paths []string
+	for _, path := range mask.GetPaths() {
+		if path == WildcardPath {
+			paths = options.expandFields(m.ProtoReflect().Descriptor(), nil, nil, paths)
+			continue
+		}
+		prefix, ok := strings.CutSuffix(path, "."+WildcardPath)
+		if !ok {
+			paths = append(paths, path)
+			continue
+		}
+		segments, err := ParsePath(prefix)
+		if err != nil {
+			paths = append(paths, path)
+			continue
+		}
+		resolved, ok := resolvePath(m.ProtoReflect(), segments, options.resolver)
+		if !ok || resolved.message == nil || isWellKnownType(resolved.message) {
+			paths = append(paths, path)
+			continue
+		}
+		paths = options.expandFields(resolved.message, segments, nil, paths)
+	}
+	return Normalize(&fieldmaskpb.FieldMask{Paths: paths})
+}
+
+// expandFields appends the paths of the leaf fields of the message at prefix.
+// The stack contains the enclosing messages being expanded, to avoid expanding recursive message fields.
+func (o *expandOptions) expandFields(
+	md protoreflect.MessageDescriptor,
+	prefix []string,
+	stack []protoreflect.FullName,
+	paths []string,
+) []string {
+	stack = append(slices.Clip(stack), md.FullName())
+	for i := 0; i < md.Fields().Len(); i++ {
+		field := md.Fields().Get(i)
+		segments := append(slices.Clip(prefix), fieldPathName(field))
+		switch {
+		case field.Message() == nil || field.IsList() || field.IsMap() || isWellKnownType(field.Message()):
+			paths = append(paths, FormatPath(segments))
+		case isOneofMember(field):
+			// paths into several members of a oneof would select each member in turn when updating
+			paths = append(paths, FormatPath(segments))
+		case o.maxDepth > 0 && len(segments) >= o.maxDepth:
+			paths = append(paths, FormatPath(segments))
+		case slices.Contains(stack, field.Message().FullName()):
+			// recursive message
+			paths = append(paths, FormatPath(segments))
+		default:
+			paths = o.expandFields(field.Message(), segments, stack, paths)
+		}
+	}
+	return paths
+}
+
+// isOneofMember returns true if the field is a member of a oneof, other than the synthetic oneof of a proto3 optional
+// field.
+func isOneofMember(field protoreflect.FieldDescriptor) bool {
+	oneof := field.ContainingOneof()
+	return oneof != nil && !oneof.IsSynthetic()
+}
+
+// isWellKnownType returns true if the message is one of the well-known types in the google.protobuf package.
+func isWellKnownType(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile() != nil && md.ParentFile().Package() == "google.protobuf"
+}
@@ -3,6 +3,11 @@ package fieldmask
 import (
 	"testing"
 
+	examplefreightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gotest.tools/v3/assert"
 )
@@ -14,3 +19,172 @@ func TestIsFullReplacement(t *testing.T) {
 	assert.Assert(t, !IsFullReplacement(&fieldmaskpb.FieldMask{Paths: []string{"foo"}}))
 	assert.Assert(t, !IsFullReplacement(nil))
 }
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		paths    []string
+		message  proto.Message
+		opts     []ExpandOption
+		expected []string
+	}{
+		{
+			name:     "empty",
+			paths:    nil,
+			message:  &library.Book{},
+			expected: nil,
+		},
+		{
+			name:     "no wildcards",
+			paths:    []string{"title", "author"},
+			message:  &library.Book{},
+			expected: []string{"author", "title"},
+		},
+		{
+			name:     "wildcard",
+			paths:    []string{"*"},
+			message:  &library.Book{},
+			expected: []string{"author", "name", "read", "title"},
+		},
+		{
+			name:     "wildcard with other paths",
+			paths:    []string{"title", "*"},
+			message:  &library.Book{},
+			expected: []string{"author", "name", "read", "title"},
+		},
+		{
+			name:    "wildcard nested",
+			paths:   []string{"*"},
+			message: &library.UpdateBookRequest{},
+			expected: []string{
+				"book.author",
+				"book.name",
+				"book.read",
+				"book.title",
+				"update_mask",
+			},
+		},
+		{
+			name:     "wildcard max depth",
+			paths:    []string{"*"},
+			message:  &library.UpdateBookRequest{},
+			opts:     []ExpandOption{WithExpandMaxDepth(1)},
+			expected: []string{"book", "update_mask"},
+		},
+		{
+			name:    "wildcard well-known types, repeated and maps",
+			paths:   []string{"*"},
+			message: &examplefreightv1.Shipment{},
+			expected: []string{
+				"annotations",
+				"create_time",
+				"delete_time",
+				"delivery_earliest_time",
+				"delivery_latest_time",
+				"destination_site",
+				"external_reference_id",
+				"line_items",
+				"name",
+				"origin_site",
+				"pickup_earliest_time",
+				"pickup_latest_time",
+				"update_time",
+			},
+		},
+		{
+			name:    "wildcard oneof message members",
+			paths:   []string{"*"},
+			message: &syntaxv1.FieldBehaviorMessage{},
+			expected: []string{
+				"field",
+				"field_behavior_message",
+				"immutable_field",
+				"map_message",
+				"map_optional_message",
+				"map_output_only_message",
+				"message_without_field_behavior",
+				"optional_field",
+				"optional_message",
+				"output_only_field",
+				"output_only_message",
+				"repeated_message",
+				"repeated_optional_message",
+				"repeated_output_only_message",
+				"small_field_behavior_message",
+				"string_list",
+				"string_map",
+			},
+		},
+		{
+			name:     "nested wildcard",
+			paths:    []string{"book.*"},
+			message:  &library.UpdateBookRequest{},
+			expected: []string{"book.author", "book.name", "book.read", "book.title"},
+		},
+		{
+			name:     "nested wildcard in repeated",
+			paths:    []string{"books.*", "next_page_token"},
+			message:  &library.ListBooksResponse{},
+			expected: []string{"books.author", "books.name", "books.read", "books.title", "next_page_token"},
+		},
+		{
+			name:     "nested wildcard max depth",
+			paths:    []string{"book.*"},
+			message:  &library.UpdateBookRequest{},
+			opts:     []ExpandOption{WithExpandMaxDepth(1)},
+			expected: []string{"book.author", "book.name", "book.read", "book.title"},
+		},
+		{
+			name:     "nested wildcard recursive",
+			paths:    []string{"message.*"},
+			message:  &syntaxv1.Message{},
+			expected: expandExpectedRecursivePaths(),
+		},
+		{
+			name:     "nested wildcard in scalar kept",
+			paths:    []string{"title.*"},
+			message:  &library.Book{},
+			expected: []string{"title.*"},
+		},
+		{
+			name:     "nested wildcard in well-known type kept",
+			paths:    []string{"create_time.*"},
+			message:  &examplefreightv1.Shipment{},
+			expected: []string{"create_time.*"},
+		},
+		{
+			name:     "nested wildcard in unknown field kept",
+			paths:    []string{"foo.*"},
+			message:  &library.Book{},
+			expected: []string{"foo.*"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Expand(&fieldmaskpb.FieldMask{Paths: tt.paths}, tt.message, tt.opts...)
+			assert.DeepEqual(t, &fieldmaskpb.FieldMask{Paths: tt.expected}, actual, protocmp.Transform())
+		})
+	}
+}
+
+func TestExpand_updateOneof(t *testing.T) {
+	t.Parallel()
+	src := &syntaxv1.FieldBehaviorMessage{
+		Oneof: &syntaxv1.FieldBehaviorMessage_FieldBehaviorMessage{
+			FieldBehaviorMessage: &syntaxv1.FieldBehaviorMessage{Field: "src"},
+		},
+	}
+	dst := &syntaxv1.FieldBehaviorMessage{}
+	Update(Expand(&fieldmaskpb.FieldMask{Paths: []string{"*"}}, src), dst, src)
+	assert.DeepEqual(t, src, dst, protocmp.Transform())
+}
+
+func expandExpectedRecursivePaths() []string {
+	var result []string
+	fields := (&syntaxv1.Message{}).ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		result = append(result, "message."+string(fields.Get(i).Name()))
+	}
+	return Normalize(&fieldmaskpb.FieldMask{Paths: result}).GetPaths()
+}
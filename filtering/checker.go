@@ -1,11 +1,15 @@
 package filtering
 
 import (
+	"encoding/base64"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Checker struct {
@@ -55,6 +59,8 @@ func (c *Checker) checkExpr(e *expr.Expr) error {
 			return c.checkDoubleLiteral(e)
 		case *expr.Constant_Int64Value:
 			return c.checkInt64Literal(e)
+		case *expr.Constant_Uint64Value:
+			return c.checkUint64Literal(e)
 		case *expr.Constant_StringValue:
 			return c.checkStringLiteral(e)
 		default:
@@ -120,6 +126,9 @@ func (c *Checker) checkCallExpr(e *expr.Expr) (err error) {
 		}
 	}()
 	callExpr := e.GetCallExpr()
+	if err := c.checkEnumComparison(e); err != nil {
+		return err
+	}
 	for _, arg := range callExpr.GetArgs() {
 		if err := c.checkExpr(arg); err != nil {
 			return err
@@ -196,6 +205,12 @@ func (c *Checker) checkCallExprBuiltinFunctionOverloads(
 				return c.errorf(callExpr.GetArgs()[0], "invalid duration")
 			}
 		}
+	case FunctionOverloadBytesString:
+		if constExpr := callExpr.GetArgs()[0].GetConstExpr(); constExpr != nil {
+			if _, err := base64.StdEncoding.DecodeString(constExpr.GetStringValue()); err != nil {
+				return c.errorf(callExpr.GetArgs()[0], "invalid bytes. Should be in standard base64 encoding")
+			}
+		}
 	case FunctionOverloadLessThanTimestampString,
 		FunctionOverloadGreaterThanTimestampString,
 		FunctionOverloadLessEqualsTimestampString,
@@ -207,6 +222,17 @@ func (c *Checker) checkCallExprBuiltinFunctionOverloads(
 				return c.errorf(callExpr.GetArgs()[0], "invalid timestamp. Should be in RFC3339 format")
 			}
 		}
+	case FunctionOverloadLessThanUintInt,
+		FunctionOverloadGreaterThanUintInt,
+		FunctionOverloadLessEqualsUintInt,
+		FunctionOverloadGreaterEqualsUintInt,
+		FunctionOverloadEqualsUintInt,
+		FunctionOverloadNotEqualsUintInt:
+		if constExpr := callExpr.GetArgs()[1].GetConstExpr(); constExpr != nil {
+			if constExpr.GetInt64Value() < 0 {
+				return c.errorf(callExpr.GetArgs()[1], "negative value can not be compared with an unsigned int")
+			}
+		}
 	case FunctionOverloadHasTimestamp:
 		if constExpr := callExpr.GetArgs()[1].GetConstExpr(); constExpr != nil {
 			if constExpr.GetStringValue() != "*" {
@@ -220,10 +246,89 @@ func (c *Checker) checkCallExprBuiltinFunctionOverloads(
 	return nil
 }
 
+// checkEnumComparison checks that the value an enum ident is compared with is a valid value of the enum.
+func (c *Checker) checkEnumComparison(e *expr.Expr) error {
+	callExpr := e.GetCallExpr()
+	if callExpr.GetFunction() != FunctionEquals && callExpr.GetFunction() != FunctionNotEquals {
+		return nil
+	}
+	if len(callExpr.GetArgs()) != 2 {
+		return nil
+	}
+	name, ok := toQualifiedName(callExpr.GetArgs()[0])
+	if !ok {
+		return nil
+	}
+	enumType, ok := c.declarations.LookupEnumIdent(name)
+	if !ok {
+		return nil
+	}
+	values := enumType.Descriptor().Values()
+	arg := callExpr.GetArgs()[1]
+	switch {
+	case arg.GetIdentExpr() != nil:
+		if values.ByName(protoreflect.Name(arg.GetIdentExpr().GetName())) != nil {
+			return nil
+		}
+		if ident, ok := c.declarations.LookupIdent(arg.GetIdentExpr().GetName()); ok &&
+			proto.Equal(ident.GetIdent().GetType(), TypeEnum(enumType)) {
+			return nil
+		}
+		return c.errorf(
+			arg,
+			"unknown value '%s' for enum '%s', valid values are: %s",
+			arg.GetIdentExpr().GetName(),
+			name,
+			enumValueNames(values),
+		)
+	case arg.GetConstExpr() != nil:
+		if _, ok := arg.GetConstExpr().GetConstantKind().(*expr.Constant_Int64Value); !ok {
+			return c.errorf(
+				arg,
+				"enum '%s' must be compared with an enum value, valid values are: %s",
+				name,
+				enumValueNames(values),
+			)
+		}
+		if !c.declarations.enumAllowsNumbers(name) {
+			return c.errorf(
+				arg,
+				"enum '%s' does not allow numeric values, valid values are: %s",
+				name,
+				enumValueNames(values),
+			)
+		}
+		number := arg.GetConstExpr().GetInt64Value()
+		if number < math.MinInt32 || number > math.MaxInt32 ||
+			values.ByNumber(protoreflect.EnumNumber(number)) == nil {
+			return c.errorf(
+				arg,
+				"unknown value %d for enum '%s', valid values are: %s",
+				number,
+				name,
+				enumValueNames(values),
+			)
+		}
+	}
+	return nil
+}
+
+func enumValueNames(values protoreflect.EnumValueDescriptors) string {
+	names := make([]string, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	return strings.Join(names, ", ")
+}
+
 func (c *Checker) checkInt64Literal(e *expr.Expr) error {
 	return c.setType(e, TypeInt)
 }
 
+func (c *Checker) checkUint64Literal(e *expr.Expr) error {
+	return c.setType(e, TypeUint)
+}
+
 func (c *Checker) checkStringLiteral(e *expr.Expr) error {
 	return c.setType(e, TypeString)
 }
@@ -327,6 +327,32 @@ func TestChecker(t *testing.T) {
 			},
 		},
 
+		{
+			filter: `fingerprint != bytes("3q2+7w==")`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("fingerprint", TypeBytes),
+			},
+		},
+
+		{
+			filter: `fingerprint = bytes("INVALID!")`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("fingerprint", TypeBytes),
+			},
+			errorContains: "invalid bytes",
+		},
+
+		{
+			filter: `fingerprint < bytes("3q2+7w==")`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("fingerprint", TypeBytes),
+			},
+			errorContains: "no matching overload",
+		},
+
 		{
 			filter: `create_time > timestamp("2006-01-02T15:04:05+07:00")`,
 			declarations: []DeclarationOption{
@@ -390,6 +416,59 @@ func TestChecker(t *testing.T) {
 			},
 		},
 
+		{
+			filter: `enum = ENUM_TOW`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareEnumIdent("enum", syntaxv1.Enum(0).Type()),
+			},
+			errorContains: "unknown value 'ENUM_TOW' for enum 'enum', valid values are: ENUM_UNSPECIFIED, ENUM_ONE, ENUM_TWO",
+		},
+
+		{
+			filter: `enum = "ENUM_ONE"`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareEnumIdent("enum", syntaxv1.Enum(0).Type()),
+			},
+			errorContains: "enum 'enum' must be compared with an enum value",
+		},
+
+		{
+			filter: `enum = 1`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareEnumIdent("enum", syntaxv1.Enum(0).Type()),
+			},
+			errorContains: "enum 'enum' does not allow numeric values",
+		},
+
+		{
+			filter: `enum = 1 OR enum != ENUM_TWO`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareEnumIdent("enum", syntaxv1.Enum(0).Type(), AllowEnumNumbers()),
+			},
+		},
+
+		{
+			filter: `enum = 3`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareEnumIdent("enum", syntaxv1.Enum(0).Type(), AllowEnumNumbers()),
+			},
+			errorContains: "unknown value 3 for enum 'enum'",
+		},
+
+		{
+			filter: `enum = enum2`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareEnumIdent("enum", syntaxv1.Enum(0).Type()),
+				DeclareEnumIdent("enum2", syntaxv1.Enum(0).Type()),
+			},
+		},
+
 		{
 			filter: `create_time = "2022-08-12 22:22:22"`,
 			declarations: []DeclarationOption{
@@ -447,6 +526,40 @@ func TestChecker(t *testing.T) {
 			},
 		},
 
+		{
+			filter: `count > 18446744073709551615u`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("count", TypeUint),
+			},
+		},
+
+		{
+			filter: `count >= 10`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("count", TypeUint),
+			},
+		},
+
+		{
+			filter: `count > -1`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("count", TypeUint),
+			},
+			errorContains: "negative value can not be compared with an unsigned int",
+		},
+
+		{
+			filter: `count > 10u`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("count", TypeInt),
+			},
+			errorContains: "no matching overload",
+		},
+
 		{
 			filter:        "<",
 			errorContains: "unexpected token <",
@@ -512,3 +625,72 @@ func TestChecker(t *testing.T) {
 		})
 	}
 }
+
+func TestChecker_arithmetic(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		filter        string
+		declarations  []DeclarationOption
+		errorContains string
+	}{
+		{
+			filter: `create_time > now() - duration("24h")`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareNowFunction(),
+				DeclareTimeArithmetic(),
+				DeclareIdent("create_time", TypeTimestamp),
+			},
+		},
+
+		{
+			filter: `expire_time - create_time < duration("1h") + ttl`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareTimeArithmetic(),
+				DeclareIdent("create_time", TypeTimestamp),
+				DeclareIdent("expire_time", TypeTimestamp),
+				DeclareIdent("ttl", TypeDuration),
+			},
+		},
+
+		{
+			filter: `create_time > now() - duration("24h")`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareIdent("create_time", TypeTimestamp),
+			},
+			errorContains: "undeclared function 'now'",
+		},
+
+		{
+			filter: `create_time > now() + now()`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareNowFunction(),
+				DeclareTimeArithmetic(),
+				DeclareIdent("create_time", TypeTimestamp),
+			},
+			errorContains: "no matching overload",
+		},
+	} {
+		t.Run(tt.filter, func(t *testing.T) {
+			t.Parallel()
+			var parser Parser
+			parser.Init(tt.filter, WithArithmetic())
+			parsedExpr, err := parser.Parse()
+			assert.NilError(t, err)
+			declarations, err := NewDeclarations(tt.declarations...)
+			assert.NilError(t, err)
+			var checker Checker
+			checker.Init(parsedExpr.GetExpr(), parsedExpr.GetSourceInfo(), declarations)
+			checkedExpr, err := checker.Check()
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, checkedExpr != nil)
+		})
+	}
+}
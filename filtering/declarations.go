@@ -65,9 +65,10 @@ func NewConstantDeclaration(name string, constantType *expr.Type, constantValue
 
 // Declarations contain declarations for type-checking filter expressions.
 type Declarations struct {
-	idents    map[string]*expr.Decl
-	functions map[string]*expr.Decl
-	enums     map[string]protoreflect.EnumType
+	idents      map[string]*expr.Decl
+	functions   map[string]*expr.Decl
+	enums       map[string]protoreflect.EnumType
+	enumNumbers map[string]bool
 }
 
 // DeclarationOption configures Declarations.
@@ -85,6 +86,31 @@ func DeclareStandardFunctions() DeclarationOption {
 	}
 }
 
+// DeclareNowFunction is a DeclarationOption that declares the optional `now` function.
+//
+// Use NowMacro to resolve calls to `now` with a clock.
+func DeclareNowFunction() DeclarationOption {
+	return func(declarations *Declarations) error {
+		return declarations.declare(StandardFunctionNow())
+	}
+}
+
+// DeclareTimeArithmetic is a DeclarationOption that declares the optional `+` and `-` functions for timestamps and
+// durations, e.g. `create_time > now() - duration("24h")`.
+func DeclareTimeArithmetic() DeclarationOption {
+	return func(declarations *Declarations) error {
+		for _, declaration := range []*expr.Decl{
+			StandardFunctionAdd(),
+			StandardFunctionSubtract(),
+		} {
+			if err := declarations.declare(declaration); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // DeclareFunction is a DeclarationOption that declares a single function and its overloads.
 func DeclareFunction(name string, overloads ...*expr.Decl_FunctionDecl_Overload) DeclarationOption {
 	return func(declarations *Declarations) error {
@@ -99,18 +125,41 @@ func DeclareIdent(name string, t *expr.Type) DeclarationOption {
 	}
 }
 
-func DeclareEnumIdent(name string, enumType protoreflect.EnumType) DeclarationOption {
+// EnumIdentOption configures an enum ident declared with DeclareEnumIdent.
+type EnumIdentOption func(*enumIdentOptions)
+
+type enumIdentOptions struct {
+	allowNumbers bool
+}
+
+// AllowEnumNumbers allows the enum ident to be compared with the numeric values of the enum, e.g. `state = 1`.
+func AllowEnumNumbers() EnumIdentOption {
+	return func(opts *enumIdentOptions) {
+		opts.allowNumbers = true
+	}
+}
+
+// DeclareEnumIdent is a DeclarationOption that declares a single enum ident and all the values of the enum.
+//
+// Enum values are declared as constants. Values that are aliases (allow_alias) of another value have the name of the
+// first value declared with the same number as their constant value.
+func DeclareEnumIdent(name string, enumType protoreflect.EnumType, opts ...EnumIdentOption) DeclarationOption {
 	return func(declarations *Declarations) error {
-		return declarations.declareEnumIdent(name, enumType)
+		var options enumIdentOptions
+		for _, opt := range opts {
+			opt(&options)
+		}
+		return declarations.declareEnumIdent(name, enumType, options)
 	}
 }
 
 // NewDeclarations creates a new set of Declarations for filter expression type-checking.
 func NewDeclarations(opts ...DeclarationOption) (*Declarations, error) {
 	d := &Declarations{
-		idents:    make(map[string]*expr.Decl),
-		functions: make(map[string]*expr.Decl),
-		enums:     make(map[string]protoreflect.EnumType),
+		idents:      make(map[string]*expr.Decl),
+		functions:   make(map[string]*expr.Decl),
+		enums:       make(map[string]protoreflect.EnumType),
+		enumNumbers: make(map[string]bool),
 	}
 	for _, opt := range opts {
 		if err := opt(d); err != nil {
@@ -135,6 +184,17 @@ func (d *Declarations) LookupEnumIdent(name string) (protoreflect.EnumType, bool
 	return result, ok
 }
 
+// declaresArithmetic returns true if the `+` or `-` functions are declared.
+func (d *Declarations) declaresArithmetic() bool {
+	_, hasAdd := d.LookupFunction(FunctionAdd)
+	_, hasSubtract := d.LookupFunction(FunctionSubtract)
+	return hasAdd || hasSubtract
+}
+
+func (d *Declarations) enumAllowsNumbers(name string) bool {
+	return d.enumNumbers[name]
+}
+
 func (d *Declarations) declareIdent(name string, t *expr.Type) error {
 	newIdent := NewIdentDeclaration(name, t)
 	if ident, ok := d.idents[name]; ok && !proto.Equal(newIdent, ident) {
@@ -156,11 +216,12 @@ func (d *Declarations) declareConstant(name string, constantType *expr.Type, con
 	return nil
 }
 
-func (d *Declarations) declareEnumIdent(name string, enumType protoreflect.EnumType) error {
+func (d *Declarations) declareEnumIdent(name string, enumType protoreflect.EnumType, options enumIdentOptions) error {
 	if _, ok := d.enums[name]; ok {
 		return fmt.Errorf("redeclaration of %s", name)
 	}
 	d.enums[name] = enumType
+	d.enumNumbers[name] = options.allowNumbers
 	enumIdentType := TypeEnum(enumType)
 	if err := d.declareIdent(name, enumIdentType); err != nil {
 		return err
@@ -169,17 +230,25 @@ func (d *Declarations) declareEnumIdent(name string, enumType protoreflect.EnumT
 		FunctionEquals,
 		FunctionNotEquals,
 	} {
-		if err := d.declareFunction(
-			fn,
+		overloads := []*expr.Decl_FunctionDecl_Overload{
 			NewFunctionOverload(fn+"_"+enumIdentType.GetMessageType(), TypeBool, enumIdentType, enumIdentType),
-		); err != nil {
+		}
+		if options.allowNumbers {
+			overloads = append(
+				overloads,
+				NewFunctionOverload(fn+"_"+enumIdentType.GetMessageType()+"_int", TypeBool, enumIdentType, TypeInt),
+			)
+		}
+		if err := d.declareFunction(fn, overloads...); err != nil {
 			return err
 		}
 	}
 	values := enumType.Descriptor().Values()
 	for i := 0; i < values.Len(); i++ {
-		valueName := string(values.Get(i).Name())
-		if err := d.declareConstant(valueName, enumIdentType, NewStringConstant(valueName)); err != nil {
+		value := values.Get(i)
+		// Aliases resolve to the first value declared with the same number.
+		canonicalName := string(values.ByNumber(value.Number()).Name())
+		if err := d.declareConstant(string(value.Name()), enumIdentType, NewStringConstant(canonicalName)); err != nil {
 			return err
 		}
 	}
@@ -225,9 +294,10 @@ func (d *Declarations) declare(decl *expr.Decl) error {
 // clone returns a shallow copy of the declarations.
 func (d *Declarations) clone() *Declarations {
 	cloned := &Declarations{
-		idents:    make(map[string]*expr.Decl, len(d.idents)),
-		functions: make(map[string]*expr.Decl, len(d.functions)),
-		enums:     make(map[string]protoreflect.EnumType, len(d.enums)),
+		idents:      make(map[string]*expr.Decl, len(d.idents)),
+		functions:   make(map[string]*expr.Decl, len(d.functions)),
+		enums:       make(map[string]protoreflect.EnumType, len(d.enums)),
+		enumNumbers: make(map[string]bool, len(d.enumNumbers)),
 	}
 	for k, v := range d.idents {
 		cloned.idents[k] = v
@@ -238,6 +308,9 @@ func (d *Declarations) clone() *Declarations {
 	for k, v := range d.enums {
 		cloned.enums[k] = v
 	}
+	for k, v := range d.enumNumbers {
+		cloned.enumNumbers[k] = v
+	}
 	return cloned
 }
 
@@ -257,4 +330,7 @@ func (d *Declarations) merge(decl *Declarations) {
 	for name, enum := range decl.enums {
 		d.enums[name] = enum
 	}
+	for name, allowNumbers := range decl.enumNumbers {
+		d.enumNumbers[name] = allowNumbers
+	}
 }
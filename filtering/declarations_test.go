@@ -0,0 +1,66 @@
+package filtering
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestDeclareEnumIdent_Alias(t *testing.T) {
+	t.Parallel()
+	fileDesc := &descriptorpb.FileDescriptorProto{
+		Name:    toPtr("alias.proto"),
+		Package: toPtr("test"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name:    toPtr("State"),
+				Options: &descriptorpb.EnumOptions{AllowAlias: toPtr(true)},
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: toPtr("STATE_UNSPECIFIED"), Number: toPtr(int32(0))},
+					{Name: toPtr("STATE_RUNNING"), Number: toPtr(int32(1))},
+					{Name: toPtr("STATE_STARTED"), Number: toPtr(int32(1))},
+				},
+			},
+		},
+	}
+	file, err := protodesc.NewFile(fileDesc, protoregistry.GlobalFiles)
+	assert.NilError(t, err)
+	enumType := dynamicpb.NewEnumType(file.Enums().ByName("State"))
+	declarations, err := NewDeclarations(
+		DeclareStandardFunctions(),
+		DeclareEnumIdent("state", enumType, AllowEnumNumbers()),
+	)
+	assert.NilError(t, err)
+	t.Run("alias resolves to first value", func(t *testing.T) {
+		t.Parallel()
+		alias, ok := declarations.LookupIdent("STATE_STARTED")
+		assert.Assert(t, ok)
+		assert.Equal(t, "STATE_RUNNING", alias.GetIdent().GetValue().GetStringValue())
+	})
+	for _, tt := range []struct {
+		filter        string
+		errorContains string
+	}{
+		{filter: `state = STATE_RUNNING`},
+		{filter: `state = STATE_STARTED`},
+		{filter: `state != 1`},
+		{
+			filter:        `state = STATE_STOPPED`,
+			errorContains: "valid values are: STATE_UNSPECIFIED, STATE_RUNNING, STATE_STARTED",
+		},
+	} {
+		t.Run(tt.filter, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseFilterString(tt.filter, declarations)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package filtering
 
 import (
+	"encoding/base64"
 	"time"
 
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
@@ -44,6 +45,18 @@ func Float(value float64) *expr.Expr {
 	}
 }
 
+func Bool(value bool) *expr.Expr {
+	return &expr.Expr{
+		ExprKind: &expr.Expr_ConstExpr{
+			ConstExpr: &expr.Constant{
+				ConstantKind: &expr.Constant_BoolValue{
+					BoolValue: value,
+				},
+			},
+		},
+	}
+}
+
 func Duration(value time.Duration) *expr.Expr {
 	return Function(FunctionDuration, String(value.String()))
 }
@@ -52,6 +65,22 @@ func Timestamp(value time.Time) *expr.Expr {
 	return Function(FunctionTimestamp, String(value.Format(time.RFC3339)))
 }
 
+func Bytes(value []byte) *expr.Expr {
+	return Function(FunctionBytes, String(base64.StdEncoding.EncodeToString(value)))
+}
+
+func Now() *expr.Expr {
+	return Function(FunctionNow)
+}
+
+func Add(lhs, rhs *expr.Expr) *expr.Expr {
+	return Function(FunctionAdd, lhs, rhs)
+}
+
+func Subtract(lhs, rhs *expr.Expr) *expr.Expr {
+	return Function(FunctionSubtract, lhs, rhs)
+}
+
 func Int(value int64) *expr.Expr {
 	return &expr.Expr{
 		ExprKind: &expr.Expr_ConstExpr{
@@ -64,6 +93,18 @@ func Int(value int64) *expr.Expr {
 	}
 }
 
+func Uint(value uint64) *expr.Expr {
+	return &expr.Expr{
+		ExprKind: &expr.Expr_ConstExpr{
+			ConstExpr: &expr.Constant{
+				ConstantKind: &expr.Constant_Uint64Value{
+					Uint64Value: value,
+				},
+			},
+		},
+	}
+}
+
 func Equals(lhs, rhs *expr.Expr) *expr.Expr {
 	return Function(FunctionEquals, lhs, rhs)
 }
@@ -83,6 +83,31 @@ func MatchAnyInt(value *int64) Matcher {
 	}
 }
 
+// MatchUint matches an expr.Constant_Uint64Value with an exact value.
+func MatchUint(value uint64) Matcher {
+	var u2 uint64
+	m := MatchAnyUint(&u2)
+	return func(exp *expr.Expr) bool {
+		return m(exp) && value == u2
+	}
+}
+
+// MatchAnyUint matches an expr.Constant_Uint64Value with any value.
+// The value of the expr is populated in argument value.
+func MatchAnyUint(value *uint64) Matcher {
+	return func(exp *expr.Expr) bool {
+		cons := exp.GetConstExpr()
+		if cons == nil {
+			return false
+		}
+		if _, ok := cons.GetConstantKind().(*expr.Constant_Uint64Value); !ok {
+			return false
+		}
+		*value = cons.GetUint64Value()
+		return true
+	}
+}
+
 // MatchText matches an expr.Expr_Ident where the name
 // of the ident matches an exact value.
 func MatchText(text string) Matcher {
@@ -65,6 +65,22 @@ func TestMatch(t *testing.T) {
 			matcher: MatchInt(3),
 			expr:    filtering.Int(1),
 		},
+		{
+			name:     "uint: match",
+			matcher:  MatchUint(3),
+			expr:     filtering.Uint(3),
+			expected: true,
+		},
+		{
+			name:    "uint: int expr",
+			matcher: MatchUint(3),
+			expr:    filtering.Int(3),
+		},
+		{
+			name:    "uint: wrong uint",
+			matcher: MatchUint(3),
+			expr:    filtering.Uint(1),
+		},
 		{
 			name:     "text: match",
 			matcher:  MatchText("text"),
@@ -195,6 +211,15 @@ func TestMatchAny(t *testing.T) {
 		assert.Check(t, matcher(exp))
 		assert.Equal(t, int64(3), val)
 	})
+	t.Run("Uint", func(t *testing.T) {
+		t.Parallel()
+		var val uint64
+		matcher := MatchAnyUint(&val)
+		exp := filtering.Uint(3)
+
+		assert.Check(t, matcher(exp))
+		assert.Equal(t, uint64(3), val)
+	})
 	t.Run("Text", func(t *testing.T) {
 		t.Parallel()
 		var val string
@@ -17,6 +17,14 @@ const (
 	FunctionHas           = ":"
 	FunctionDuration      = "duration"
 	FunctionTimestamp     = "timestamp"
+	FunctionBytes         = "bytes"
+)
+
+// Optional function names.
+const (
+	FunctionNow      = "now"
+	FunctionAdd      = "+"
+	FunctionSubtract = "-"
 )
 
 // StandardFunctionDeclarations returns declarations for all standard functions and their standard overloads.
@@ -24,6 +32,7 @@ func StandardFunctionDeclarations() []*expr.Decl {
 	return []*expr.Decl{
 		StandardFunctionTimestamp(),
 		StandardFunctionDuration(),
+		StandardFunctionBytes(),
 		StandardFunctionHas(),
 		StandardFunctionAnd(),
 		StandardFunctionOr(),
@@ -63,6 +72,72 @@ func StandardFunctionDuration() *expr.Decl {
 	)
 }
 
+// Bytes overloads.
+const (
+	FunctionOverloadBytesString = FunctionBytes + "_string"
+)
+
+// StandardFunctionBytes returns a declaration for the standard `bytes` function and all its standard overloads.
+//
+// The string argument is the standard base64 encoding of the bytes value.
+func StandardFunctionBytes() *expr.Decl {
+	return NewFunctionDeclaration(
+		FunctionBytes,
+		NewFunctionOverload(FunctionOverloadBytesString, TypeBytes, TypeString),
+	)
+}
+
+// Now overloads.
+const (
+	FunctionOverloadNow = FunctionNow
+)
+
+// StandardFunctionNow returns a declaration for the optional `now` function and all its overloads.
+//
+// The `now` function is not part of the standard function declarations, see DeclareNowFunction.
+func StandardFunctionNow() *expr.Decl {
+	return NewFunctionDeclaration(
+		FunctionNow,
+		NewFunctionOverload(FunctionOverloadNow, TypeTimestamp),
+	)
+}
+
+// Add overloads.
+const (
+	FunctionOverloadAddTimestampDuration = FunctionAdd + "_timestamp_duration"
+	FunctionOverloadAddDuration          = FunctionAdd + "_duration"
+)
+
+// StandardFunctionAdd returns a declaration for the optional `+` function and all its overloads.
+//
+// The `+` function is not part of the standard function declarations, see DeclareTimeArithmetic.
+func StandardFunctionAdd() *expr.Decl {
+	return NewFunctionDeclaration(
+		FunctionAdd,
+		NewFunctionOverload(FunctionOverloadAddTimestampDuration, TypeTimestamp, TypeTimestamp, TypeDuration),
+		NewFunctionOverload(FunctionOverloadAddDuration, TypeDuration, TypeDuration, TypeDuration),
+	)
+}
+
+// Subtract overloads.
+const (
+	FunctionOverloadSubtractTimestampDuration = FunctionSubtract + "_timestamp_duration"
+	FunctionOverloadSubtractTimestamp         = FunctionSubtract + "_timestamp"
+	FunctionOverloadSubtractDuration          = FunctionSubtract + "_duration"
+)
+
+// StandardFunctionSubtract returns a declaration for the optional `-` function and all its overloads.
+//
+// The `-` function is not part of the standard function declarations, see DeclareTimeArithmetic.
+func StandardFunctionSubtract() *expr.Decl {
+	return NewFunctionDeclaration(
+		FunctionSubtract,
+		NewFunctionOverload(FunctionOverloadSubtractTimestampDuration, TypeTimestamp, TypeTimestamp, TypeDuration),
+		NewFunctionOverload(FunctionOverloadSubtractTimestamp, TypeDuration, TypeTimestamp, TypeTimestamp),
+		NewFunctionOverload(FunctionOverloadSubtractDuration, TypeDuration, TypeDuration, TypeDuration),
+	)
+}
+
 // Has overloads.
 const (
 	FunctionOverloadHasString          = FunctionHas + "_string"
@@ -125,6 +200,8 @@ func StandardFunctionNot() *expr.Decl {
 // LessThan overloads.
 const (
 	FunctionOverloadLessThanInt             = FunctionLessThan + "_int"
+	FunctionOverloadLessThanUint            = FunctionLessThan + "_uint"
+	FunctionOverloadLessThanUintInt         = FunctionLessThan + "_uint_int"
 	FunctionOverloadLessThanFloat           = FunctionLessThan + "_float"
 	FunctionOverloadLessThanFloatInt        = FunctionLessThan + "_float_int"
 	FunctionOverloadLessThanString          = FunctionLessThan + "_string"
@@ -138,6 +215,8 @@ func StandardFunctionLessThan() *expr.Decl {
 	return NewFunctionDeclaration(
 		FunctionLessThan,
 		NewFunctionOverload(FunctionOverloadLessThanInt, TypeBool, TypeInt, TypeInt),
+		NewFunctionOverload(FunctionOverloadLessThanUint, TypeBool, TypeUint, TypeUint),
+		NewFunctionOverload(FunctionOverloadLessThanUintInt, TypeBool, TypeUint, TypeInt),
 		NewFunctionOverload(FunctionOverloadLessThanFloat, TypeBool, TypeFloat, TypeFloat),
 		NewFunctionOverload(FunctionOverloadLessThanFloatInt, TypeBool, TypeFloat, TypeInt),
 		NewFunctionOverload(FunctionOverloadLessThanString, TypeBool, TypeString, TypeString),
@@ -150,6 +229,8 @@ func StandardFunctionLessThan() *expr.Decl {
 // GreaterThan overloads.
 const (
 	FunctionOverloadGreaterThanInt             = FunctionGreaterThan + "_int"
+	FunctionOverloadGreaterThanUint            = FunctionGreaterThan + "_uint"
+	FunctionOverloadGreaterThanUintInt         = FunctionGreaterThan + "_uint_int"
 	FunctionOverloadGreaterThanFloat           = FunctionGreaterThan + "_float"
 	FunctionOverloadGreaterThanFloatInt        = FunctionGreaterThan + "_float_int"
 	FunctionOverloadGreaterThanString          = FunctionGreaterThan + "_string"
@@ -163,6 +244,8 @@ func StandardFunctionGreaterThan() *expr.Decl {
 	return NewFunctionDeclaration(
 		FunctionGreaterThan,
 		NewFunctionOverload(FunctionOverloadGreaterThanInt, TypeBool, TypeInt, TypeInt),
+		NewFunctionOverload(FunctionOverloadGreaterThanUint, TypeBool, TypeUint, TypeUint),
+		NewFunctionOverload(FunctionOverloadGreaterThanUintInt, TypeBool, TypeUint, TypeInt),
 		NewFunctionOverload(FunctionOverloadGreaterThanFloat, TypeBool, TypeFloat, TypeFloat),
 		NewFunctionOverload(FunctionOverloadGreaterThanFloatInt, TypeBool, TypeFloat, TypeInt),
 		NewFunctionOverload(FunctionOverloadGreaterThanString, TypeBool, TypeString, TypeString),
@@ -175,6 +258,8 @@ func StandardFunctionGreaterThan() *expr.Decl {
 // LessEquals overloads.
 const (
 	FunctionOverloadLessEqualsInt             = FunctionLessEquals + "_int"
+	FunctionOverloadLessEqualsUint            = FunctionLessEquals + "_uint"
+	FunctionOverloadLessEqualsUintInt         = FunctionLessEquals + "_uint_int"
 	FunctionOverloadLessEqualsFloat           = FunctionLessEquals + "_float"
 	FunctionOverloadLessEqualsFloatInt        = FunctionLessEquals + "_float_int"
 	FunctionOverloadLessEqualsString          = FunctionLessEquals + "_string"
@@ -188,6 +273,8 @@ func StandardFunctionLessEquals() *expr.Decl {
 	return NewFunctionDeclaration(
 		FunctionLessEquals,
 		NewFunctionOverload(FunctionOverloadLessEqualsInt, TypeBool, TypeInt, TypeInt),
+		NewFunctionOverload(FunctionOverloadLessEqualsUint, TypeBool, TypeUint, TypeUint),
+		NewFunctionOverload(FunctionOverloadLessEqualsUintInt, TypeBool, TypeUint, TypeInt),
 		NewFunctionOverload(FunctionOverloadLessEqualsFloat, TypeBool, TypeFloat, TypeFloat),
 		NewFunctionOverload(FunctionOverloadLessEqualsFloatInt, TypeBool, TypeFloat, TypeInt),
 		NewFunctionOverload(FunctionOverloadLessEqualsString, TypeBool, TypeString, TypeString),
@@ -200,6 +287,8 @@ func StandardFunctionLessEquals() *expr.Decl {
 // GreaterEquals overloads.
 const (
 	FunctionOverloadGreaterEqualsInt             = FunctionGreaterEquals + "_int"
+	FunctionOverloadGreaterEqualsUint            = FunctionGreaterEquals + "_uint"
+	FunctionOverloadGreaterEqualsUintInt         = FunctionGreaterEquals + "_uint_int"
 	FunctionOverloadGreaterEqualsFloat           = FunctionGreaterEquals + "_float"
 	FunctionOverloadGreaterEqualsFloatInt        = FunctionGreaterEquals + "_float_int"
 	FunctionOverloadGreaterEqualsString          = FunctionGreaterEquals + "_string"
@@ -213,6 +302,8 @@ func StandardFunctionGreaterEquals() *expr.Decl {
 	return NewFunctionDeclaration(
 		FunctionGreaterEquals,
 		NewFunctionOverload(FunctionOverloadGreaterEqualsInt, TypeBool, TypeInt, TypeInt),
+		NewFunctionOverload(FunctionOverloadGreaterEqualsUint, TypeBool, TypeUint, TypeUint),
+		NewFunctionOverload(FunctionOverloadGreaterEqualsUintInt, TypeBool, TypeUint, TypeInt),
 		NewFunctionOverload(FunctionOverloadGreaterEqualsFloat, TypeBool, TypeFloat, TypeFloat),
 		NewFunctionOverload(FunctionOverloadGreaterEqualsFloatInt, TypeBool, TypeFloat, TypeInt),
 		NewFunctionOverload(FunctionOverloadGreaterEqualsString, TypeBool, TypeString, TypeString),
@@ -226,12 +317,15 @@ func StandardFunctionGreaterEquals() *expr.Decl {
 const (
 	FunctionOverloadEqualsBool            = FunctionEquals + "_bool"
 	FunctionOverloadEqualsInt             = FunctionEquals + "_int"
+	FunctionOverloadEqualsUint            = FunctionEquals + "_uint"
+	FunctionOverloadEqualsUintInt         = FunctionEquals + "_uint_int"
 	FunctionOverloadEqualsFloat           = FunctionEquals + "_float"
 	FunctionOverloadEqualsFloatInt        = FunctionEquals + "_float_int"
 	FunctionOverloadEqualsString          = FunctionEquals + "_string"
 	FunctionOverloadEqualsTimestamp       = FunctionEquals + "_timestamp"
 	FunctionOverloadEqualsTimestampString = FunctionEquals + "_timestamp_string"
 	FunctionOverloadEqualsDuration        = FunctionEquals + "_duration"
+	FunctionOverloadEqualsBytes           = FunctionEquals + "_bytes"
 )
 
 // StandardFunctionEquals returns a declaration for the standard '=' function and all its standard overloads.
@@ -240,12 +334,15 @@ func StandardFunctionEquals() *expr.Decl {
 		FunctionEquals,
 		NewFunctionOverload(FunctionOverloadEqualsBool, TypeBool, TypeBool, TypeBool),
 		NewFunctionOverload(FunctionOverloadEqualsInt, TypeBool, TypeInt, TypeInt),
+		NewFunctionOverload(FunctionOverloadEqualsUint, TypeBool, TypeUint, TypeUint),
+		NewFunctionOverload(FunctionOverloadEqualsUintInt, TypeBool, TypeUint, TypeInt),
 		NewFunctionOverload(FunctionOverloadEqualsFloat, TypeBool, TypeFloat, TypeFloat),
 		NewFunctionOverload(FunctionOverloadEqualsFloatInt, TypeBool, TypeFloat, TypeInt),
 		NewFunctionOverload(FunctionOverloadEqualsString, TypeBool, TypeString, TypeString),
 		NewFunctionOverload(FunctionOverloadEqualsTimestamp, TypeBool, TypeTimestamp, TypeTimestamp),
 		NewFunctionOverload(FunctionOverloadEqualsTimestampString, TypeBool, TypeTimestamp, TypeString),
 		NewFunctionOverload(FunctionOverloadEqualsDuration, TypeBool, TypeDuration, TypeDuration),
+		NewFunctionOverload(FunctionOverloadEqualsBytes, TypeBool, TypeBytes, TypeBytes),
 	)
 }
 
@@ -253,12 +350,15 @@ func StandardFunctionEquals() *expr.Decl {
 const (
 	FunctionOverloadNotEqualsBool            = FunctionNotEquals + "_bool"
 	FunctionOverloadNotEqualsInt             = FunctionNotEquals + "_int"
+	FunctionOverloadNotEqualsUint            = FunctionNotEquals + "_uint"
+	FunctionOverloadNotEqualsUintInt         = FunctionNotEquals + "_uint_int"
 	FunctionOverloadNotEqualsFloat           = FunctionNotEquals + "_float"
 	FunctionOverloadNotEqualsFloatInt        = FunctionNotEquals + "_float_int"
 	FunctionOverloadNotEqualsString          = FunctionNotEquals + "_string"
 	FunctionOverloadNotEqualsTimestamp       = FunctionNotEquals + "_timestamp"
 	FunctionOverloadNotEqualsTimestampString = FunctionNotEquals + "_timestamp_string"
 	FunctionOverloadNotEqualsDuration        = FunctionNotEquals + "_duration"
+	FunctionOverloadNotEqualsBytes           = FunctionNotEquals + "_bytes"
 )
 
 // StandardFunctionNotEquals returns a declaration for the standard '!=' function and all its standard overloads.
@@ -267,11 +367,14 @@ func StandardFunctionNotEquals() *expr.Decl {
 		FunctionNotEquals,
 		NewFunctionOverload(FunctionOverloadNotEqualsBool, TypeBool, TypeBool, TypeBool),
 		NewFunctionOverload(FunctionOverloadNotEqualsInt, TypeBool, TypeInt, TypeInt),
+		NewFunctionOverload(FunctionOverloadNotEqualsUint, TypeBool, TypeUint, TypeUint),
+		NewFunctionOverload(FunctionOverloadNotEqualsUintInt, TypeBool, TypeUint, TypeInt),
 		NewFunctionOverload(FunctionOverloadNotEqualsFloat, TypeBool, TypeFloat, TypeFloat),
 		NewFunctionOverload(FunctionOverloadNotEqualsFloatInt, TypeBool, TypeFloat, TypeInt),
 		NewFunctionOverload(FunctionOverloadNotEqualsString, TypeBool, TypeString, TypeString),
 		NewFunctionOverload(FunctionOverloadNotEqualsTimestamp, TypeBool, TypeTimestamp, TypeTimestamp),
 		NewFunctionOverload(FunctionOverloadNotEqualsTimestampString, TypeBool, TypeTimestamp, TypeString),
 		NewFunctionOverload(FunctionOverloadNotEqualsDuration, TypeBool, TypeDuration, TypeDuration),
+		NewFunctionOverload(FunctionOverloadNotEqualsBytes, TypeBool, TypeBytes, TypeBytes),
 	)
 }
@@ -71,13 +71,19 @@ func (l *Lexer) Lex() (Token, error) {
 			for l.sniff(isHexDigit) {
 				_, _ = l.nextRune()
 			}
+			l.lexUnsignedSuffix()
 			return l.emit(TokenTypeHexNumber)
 		}
 		for l.sniff(unicode.IsDigit) {
 			_, _ = l.nextRune()
 		}
+		l.lexUnsignedSuffix()
 		return l.emit(TokenTypeNumber)
 	}
+	// Plus operator? A plus followed by text is lexed as text, such as in `c++` or `+46`.
+	if r == '+' && !l.sniff(isText) {
+		return l.emit(TokenTypePlus)
+	}
 	// Space?
 	if unicode.IsSpace(r) {
 		for l.sniff(unicode.IsSpace) {
@@ -165,6 +171,20 @@ func (l *Lexer) sniffRune(want rune) bool {
 	return r == want
 }
 
+// lexUnsignedSuffix consumes a trailing `u` or `U` of an unsigned number literal, such as `42u`.
+// The suffix is only consumed when it is not the start of a longer text.
+func (l *Lexer) lexUnsignedSuffix() {
+	remaining := l.remainingFilter()
+	r, n := utf8.DecodeRuneInString(remaining)
+	if r != 'u' && r != 'U' {
+		return
+	}
+	if next, _ := utf8.DecodeRuneInString(remaining[n:]); isText(next) {
+		return
+	}
+	_, _ = l.nextRune()
+}
+
 func (l *Lexer) errorf(format string, args ...interface{}) error {
 	return &lexError{
 		filter:   l.filter,
@@ -306,6 +306,57 @@ func TestLexer(t *testing.T) {
 			},
 		},
 
+		{
+			filter: `foo = 42u AND bar = 0xffU`,
+			expected: []Token{
+				{Position: Position{Offset: 0, Column: 1, Line: 1}, Type: TokenTypeText, Value: "foo"},
+				{Position: Position{Offset: 3, Column: 4, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 4, Column: 5, Line: 1}, Type: TokenTypeEquals, Value: "="},
+				{Position: Position{Offset: 5, Column: 6, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 6, Column: 7, Line: 1}, Type: TokenTypeNumber, Value: "42u"},
+				{Position: Position{Offset: 9, Column: 10, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 10, Column: 11, Line: 1}, Type: TokenTypeAnd, Value: "AND"},
+				{Position: Position{Offset: 13, Column: 14, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 14, Column: 15, Line: 1}, Type: TokenTypeText, Value: "bar"},
+				{Position: Position{Offset: 17, Column: 18, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 18, Column: 19, Line: 1}, Type: TokenTypeEquals, Value: "="},
+				{Position: Position{Offset: 19, Column: 20, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 20, Column: 21, Line: 1}, Type: TokenTypeHexNumber, Value: "0xffU"},
+			},
+		},
+
+		{
+			filter: `now() + d`,
+			expected: []Token{
+				{Position: Position{Offset: 0, Column: 1, Line: 1}, Type: TokenTypeText, Value: "now"},
+				{Position: Position{Offset: 3, Column: 4, Line: 1}, Type: TokenTypeLeftParen, Value: "("},
+				{Position: Position{Offset: 4, Column: 5, Line: 1}, Type: TokenTypeRightParen, Value: ")"},
+				{Position: Position{Offset: 5, Column: 6, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 6, Column: 7, Line: 1}, Type: TokenTypePlus, Value: "+"},
+				{Position: Position{Offset: 7, Column: 8, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 8, Column: 9, Line: 1}, Type: TokenTypeText, Value: "d"},
+			},
+		},
+
+		{
+			filter: `phone = +46`,
+			expected: []Token{
+				{Position: Position{Offset: 0, Column: 1, Line: 1}, Type: TokenTypeText, Value: "phone"},
+				{Position: Position{Offset: 5, Column: 6, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 6, Column: 7, Line: 1}, Type: TokenTypeEquals, Value: "="},
+				{Position: Position{Offset: 7, Column: 8, Line: 1}, Type: TokenTypeWhitespace, Value: " "},
+				{Position: Position{Offset: 8, Column: 9, Line: 1}, Type: TokenTypeText, Value: "+46"},
+			},
+		},
+
+		{
+			filter: `42units`,
+			expected: []Token{
+				{Position: Position{Offset: 0, Column: 1, Line: 1}, Type: TokenTypeNumber, Value: "42"},
+				{Position: Position{Offset: 2, Column: 3, Line: 1}, Type: TokenTypeText, Value: "units"},
+			},
+		},
+
 		{
 			filter:        `a = "foo`,
 			errorContains: "unterminated string",
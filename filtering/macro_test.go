@@ -2,6 +2,7 @@ package filtering
 
 import (
 	"testing"
+	"time"
 
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -436,6 +437,34 @@ func TestApplyMacros(t *testing.T) {
 			expected: Equals(Text("renamed_name"), String("test")),
 		},
 
+		{
+			name:   "now macro with fixed clock",
+			filter: `create_time > now() - duration("24h")`,
+			declarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareNowFunction(),
+				DeclareTimeArithmetic(),
+				DeclareIdent("create_time", TypeTimestamp),
+			},
+			macros: []Macro{
+				NowMacro(func() time.Time {
+					return time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+				}),
+			},
+			macroDeclarations: []DeclarationOption{
+				DeclareStandardFunctions(),
+				DeclareTimeArithmetic(),
+				DeclareIdent("create_time", TypeTimestamp),
+			},
+			expected: GreaterThan(
+				Text("create_time"),
+				Subtract(
+					Function(FunctionTimestamp, String("2024-01-02T03:04:05.0000006Z")),
+					Function(FunctionDuration, String("24h")),
+				),
+			),
+		},
+
 		{
 			name:   "complex nested structure with multiple macro matches",
 			filter: `(a = 1 AND b = 2) OR (c = 3 AND d = 4)`,
@@ -0,0 +1,18 @@
+package filtering
+
+import (
+	"time"
+)
+
+// NowMacro returns a Macro that replaces calls to the `now` function with a timestamp of the time given by clock.
+//
+// Evaluators and transpilers can apply the macro to resolve `now` from an injected clock, e.g. a fixed clock in tests.
+func NowMacro(clock func() time.Time) Macro {
+	return func(cursor *Cursor) {
+		callExpr := cursor.Expr().GetCallExpr()
+		if callExpr.GetFunction() != FunctionNow || len(callExpr.GetArgs()) != 0 {
+			return
+		}
+		cursor.Replace(Function(FunctionTimestamp, String(clock().UTC().Format(time.RFC3339Nano))))
+	}
+}
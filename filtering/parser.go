@@ -12,20 +12,38 @@ import (
 
 // Parser for filter expressions.
 type Parser struct {
-	filter    string
-	lexer     Lexer
-	id        int64
-	positions []int32
+	filter     string
+	lexer      Lexer
+	id         int64
+	positions  []int32
+	arithmetic bool
+}
+
+// ParserOption configures a Parser.
+type ParserOption func(*Parser)
+
+// WithArithmetic makes the parser parse `+` and `-` between operands as calls to the `+` and `-` functions,
+// e.g. `now() - duration("24h")`. See DeclareTimeArithmetic.
+//
+// Without arithmetic, a standalone `+` is parsed as text, such as in the sequence `a + b`, and a standalone `-`
+// between operands is a syntax error.
+func WithArithmetic() ParserOption {
+	return func(p *Parser) {
+		p.arithmetic = true
+	}
 }
 
 // Init (re-)initializes the parser to parse the provided filter.
-func (p *Parser) Init(filter string) {
+func (p *Parser) Init(filter string, opts ...ParserOption) {
 	filter = strings.TrimSpace(filter)
 	*p = Parser{
 		filter:    filter,
 		positions: p.positions[:0],
 		id:        -1,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
 	p.lexer.Init(filter)
 }
 
@@ -276,6 +294,10 @@ func (p *Parser) ParseRestriction() (_ *expr.Expr, err error) {
 // EBNF
 //
 //	comparable
+//	  : operand {WS (PLUS | MINUS) WS operand} (custom, see WithArithmetic)
+//	  ;
+//
+//	operand
 //	  : member
 //	  | function
 //	  | number (custom)
@@ -287,9 +309,39 @@ func (p *Parser) ParseComparable() (_ *expr.Expr, err error) {
 			err = p.wrapf(err, start, "comparable")
 		}
 	}()
+	result, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for p.arithmetic {
+		var function string
+		var operatorTokenType TokenType
+		switch {
+		case p.sniffTokens(TokenTypeWhitespace, TokenTypePlus, TokenTypeWhitespace):
+			function, operatorTokenType = FunctionAdd, TokenTypePlus
+		case p.sniffTokens(TokenTypeWhitespace, TokenTypeMinus, TokenTypeWhitespace):
+			function, operatorTokenType = FunctionSubtract, TokenTypeMinus
+		default:
+			return result, nil
+		}
+		_ = p.eatTokens(TokenTypeWhitespace, operatorTokenType, TokenTypeWhitespace)
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		result = parsedFunction(p.nextID(start), function, result, operand)
+	}
+	return result, nil
+}
+
+func (p *Parser) parseOperand() (*expr.Expr, error) {
 	if function, ok := p.TryParseFunction(); ok {
 		return function, nil
 	}
+	if p.sniffUint() {
+		// report invalid unsigned literals, such as out of range values, instead of parsing them as members
+		return p.ParseNumber()
+	}
 	if number, ok := p.TryParseNumber(); ok {
 		return number, nil
 	}
@@ -321,7 +373,7 @@ func (p *Parser) ParseMember() (_ *expr.Expr, err error) {
 			err = p.wrapf(err, start, "member")
 		}
 	}()
-	valueToken, err := p.parseToken(TokenType.IsValue)
+	valueToken, err := p.parseToken(p.isValue)
 	if err != nil {
 		return nil, err
 	}
@@ -341,7 +393,7 @@ func (p *Parser) ParseMember() (_ *expr.Expr, err error) {
 	}
 	value := parsedText(p.nextID(valueToken.Position), unquoted)
 	_ = p.eatTokens(TokenTypeDot)
-	firstFieldToken, err := p.parseToken(TokenType.IsField)
+	firstFieldToken, err := p.parseToken(isMemberField)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +406,7 @@ func (p *Parser) ParseMember() (_ *expr.Expr, err error) {
 		if err := p.eatTokens(TokenTypeDot); err != nil {
 			break
 		}
-		fieldToken, err := p.parseToken(TokenType.IsField)
+		fieldToken, err := p.parseToken(isMemberField)
 		if err != nil {
 			return nil, err
 		}
@@ -471,6 +523,7 @@ func (p *Parser) ParseComposite() (_ *expr.Expr, err error) {
 //
 //	number
 //	  : float
+//	  | uint (custom)
 //	  | int
 //	  ;
 //
@@ -478,6 +531,11 @@ func (p *Parser) ParseComposite() (_ *expr.Expr, err error) {
 //	  : MINUS? (NUMBER DOT NUMBER* | DOT NUMBER) EXP?
 //	  ;
 //
+//	uint
+//	  : NUMBER ("u" | "U")
+//	  | HEX ("u" | "U")
+//	  ;
+//
 //	int
 //	  : MINUS? NUMBER
 //	  | MINUS? HEX
@@ -489,6 +547,9 @@ func (p *Parser) ParseNumber() (_ *expr.Expr, err error) {
 			err = p.wrapf(err, start, "number")
 		}
 	}()
+	if p.sniffUint() {
+		return p.ParseUint()
+	}
 	if float, ok := p.TryParseFloat(); ok {
 		return float, nil
 	}
@@ -574,6 +635,49 @@ func (p *Parser) TryParseFloat() (*expr.Expr, bool) {
 	return result, true
 }
 
+// ParseUint parses an unsigned int.
+//
+// EBNF
+//
+//	uint
+//	  : NUMBER ("u" | "U")
+//	  | HEX ("u" | "U")
+//	  ;
+func (p *Parser) ParseUint() (_ *expr.Expr, err error) {
+	start := p.lexer.Position()
+	defer func() {
+		if err != nil {
+			err = p.wrapf(err, start, "uint")
+		}
+	}()
+	token, err := p.parseToken(TokenTypeNumber.Test, TokenTypeHexNumber.Test)
+	if err != nil {
+		return nil, err
+	}
+	value := strings.TrimRight(token.Value, "uU")
+	if len(value) == len(token.Value) {
+		return nil, p.errorf(token.Position, "expected unsigned suffix")
+	}
+	uintValue, err := strconv.ParseUint(value, 0, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, p.errorf(token.Position, "uint literal out of range: %s", token.Value)
+		}
+		return nil, err
+	}
+	return parsedUint(p.nextID(start), uintValue), nil
+}
+
+func (p *Parser) TryParseUint() (*expr.Expr, bool) {
+	start := *p
+	result, err := p.ParseUint()
+	if err != nil {
+		*p = start
+		return nil, false
+	}
+	return result, true
+}
+
 // ParseInt parses an int.
 //
 // EBNF
@@ -652,6 +756,30 @@ func (p *Parser) sniff(fns ...func(TokenType) bool) bool {
 	return true
 }
 
+// isValue returns true if the token type is a value. Without arithmetic, a standalone `+` is a text value.
+func (p *Parser) isValue(t TokenType) bool {
+	return t.IsValue() || (!p.arithmetic && t == TokenTypePlus)
+}
+
+// isMemberField returns true if the token type is a field of a member. Operators are field names after a dot, such as
+// in `a.+`.
+func isMemberField(t TokenType) bool {
+	return t.IsField() || t == TokenTypePlus || t == TokenTypeMinus
+}
+
+// sniffUint returns true if the next token is a number with an unsigned suffix.
+func (p *Parser) sniffUint() bool {
+	start := *p
+	defer func() {
+		*p = start
+	}()
+	token, err := p.lexer.Lex()
+	if err != nil || (token.Type != TokenTypeNumber && token.Type != TokenTypeHexNumber) {
+		return false
+	}
+	return strings.HasSuffix(token.Value, "u") || strings.HasSuffix(token.Value, "U")
+}
+
 func (p *Parser) sniffTokens(wantTokenTypes ...TokenType) bool {
 	start := *p
 	defer func() {
@@ -13,6 +13,7 @@ func TestParser(t *testing.T) {
 	t.Parallel()
 	for _, tt := range []struct {
 		filter        string
+		opts          []ParserOption
 		expected      *expr.Expr
 		errorContains string
 	}{
@@ -264,6 +265,84 @@ func TestParser(t *testing.T) {
 			expected: Equals(Member(Text("annotations"), "schedule"), String("test")),
 		},
 
+		{
+			filter:   `count > 18446744073709551615u`,
+			expected: GreaterThan(Text("count"), Uint(18446744073709551615)),
+		},
+
+		{
+			filter:   `count = 0xFFU`,
+			expected: Equals(Text("count"), Uint(255)),
+		},
+
+		{
+			filter:        `count = 18446744073709551616u`,
+			errorContains: "uint literal out of range: 18446744073709551616u",
+		},
+
+		{
+			filter:   `create_time > now() - duration("24h")`,
+			opts:     []ParserOption{WithArithmetic()},
+			expected: GreaterThan(Text("create_time"), Subtract(Now(), Function(FunctionDuration, String("24h")))),
+		},
+
+		{
+			filter: `expire_time <= create_time + ttl - duration("1h")`,
+			opts:   []ParserOption{WithArithmetic()},
+			expected: LessEquals(
+				Text("expire_time"),
+				Subtract(Add(Text("create_time"), Text("ttl")), Function(FunctionDuration, String("1h"))),
+			),
+		},
+
+		{
+			filter:   `a + b`,
+			opts:     []ParserOption{WithArithmetic()},
+			expected: Add(Text("a"), Text("b")),
+		},
+
+		{
+			filter:   `a + b`,
+			expected: Sequence(Text("a"), Text("+"), Text("b")),
+		},
+
+		{
+			filter:        `a - b`,
+			errorContains: "unexpected token WS",
+		},
+
+		{
+			filter:        `create_time > now() - duration("24h")`,
+			errorContains: "unexpected token WS",
+		},
+
+		{
+			filter:   `a -b`,
+			expected: Sequence(Text("a"), Not(Text("b"))),
+		},
+
+		{
+			filter:   `a -b`,
+			opts:     []ParserOption{WithArithmetic()},
+			expected: Sequence(Text("a"), Not(Text("b"))),
+		},
+
+		{
+			filter:   `c++`,
+			expected: Text("c++"),
+		},
+
+		{
+			filter:   `a.+`,
+			expected: Member(Text("a"), "+"),
+		},
+
+		{
+			filter:   `a.+ + b.-`,
+			opts:     []ParserOption{WithArithmetic()},
+			expected: Add(Member(Text("a"), "+"), Member(Text("b"), "-")),
+		},
+
 		{
 			filter:        "<",
 			errorContains: "unexpected token <",
@@ -287,7 +366,7 @@ func TestParser(t *testing.T) {
 		t.Run(tt.filter, func(t *testing.T) {
 			t.Parallel()
 			var parser Parser
-			parser.Init(tt.filter)
+			parser.Init(tt.filter, tt.opts...)
 			actual, err := parser.Parse()
 			if tt.errorContains != "" {
 				if actual != nil {
@@ -12,6 +12,9 @@ type FilterOption func(opts *filterOptions)
 
 type filterOptions struct {
 	filterableFields []string
+	enumNumbers      bool
+	uintAsInt        bool
+	bytesAsString    bool
 }
 
 // WithFilterableFields marks the given fields as filterable.
@@ -33,8 +36,48 @@ func WithFilterableFields(fields ...string) FilterOption {
 	}
 }
 
+// WithEnumNumbers allows filterable enum fields to be compared with the numeric values of the enum.
+// See AllowEnumNumbers.
+//
+// EXPERIMENTAL: This option is experimental and may be changed or removed in the future.
+func WithEnumNumbers() FilterOption {
+	return func(opts *filterOptions) {
+		opts.enumNumbers = true
+	}
+}
+
+// WithUintAsInt declares unsigned integer fields (uint32, uint64, fixed32 and fixed64) with TypeInt instead of
+// TypeUint, as in previous versions.
+//
+// By default, unsigned integer fields are declared with TypeUint, and comparisons with int literals resolve to the
+// mixed uint overloads, such as FunctionOverloadGreaterEqualsUintInt, instead of the int overloads, such as
+// FunctionOverloadGreaterEqualsInt. Use this option to keep transpilers that only handle the int overloads working,
+// at the cost of not supporting values above math.MaxInt64.
+//
+// EXPERIMENTAL: This option is experimental and may be changed or removed in the future.
+func WithUintAsInt() FilterOption {
+	return func(opts *filterOptions) {
+		opts.uintAsInt = true
+	}
+}
+
+// WithBytesAsString declares bytes fields with TypeString instead of TypeBytes, as in previous versions.
+//
+// By default, bytes fields are declared with TypeBytes, and are compared with bytes literals, such as
+// bytes_field = bytes("3q2+7w=="), instead of string literals. Use this option to keep filters that compare bytes
+// fields with string literals working.
+//
+// EXPERIMENTAL: This option is experimental and may be changed or removed in the future.
+func WithBytesAsString() FilterOption {
+	return func(opts *filterOptions) {
+		opts.bytesAsString = true
+	}
+}
+
 // DeclareProtoMessageIdents returns declaration options for all fields marked as filterable in the proto message.
 // By default, no fields are marked as filterable. To mark a field as filterable, use the WithFilterableFields option.
+// Unsigned integer fields are declared with TypeUint, see WithUintAsInt.
+// Bytes fields are declared with TypeBytes, see WithBytesAsString.
 // EXPERIMENTAL: This function is experimental and may be changed or removed in the future.
 func DeclareProtoMessageIdents(msg proto.Message, opts ...FilterOption) []DeclarationOption {
 	options := filterOptions{}
@@ -99,7 +142,11 @@ func messageOptions(
 		case protoreflect.EnumKind:
 			// Use proper enum type declaration for better type safety and validation
 			enumType := dynamicpb.NewEnumType(field.Enum())
-			opts = append(opts, DeclareEnumIdent(currPath, enumType))
+			var enumOpts []EnumIdentOption
+			if options.enumNumbers {
+				enumOpts = append(enumOpts, AllowEnumNumbers())
+			}
+			opts = append(opts, DeclareEnumIdent(currPath, enumType, enumOpts...))
 		case protoreflect.BoolKind:
 			opts = append(opts, DeclareIdent(currPath, TypeBool))
 		case protoreflect.Int32Kind,
@@ -113,14 +160,20 @@ func messageOptions(
 			protoreflect.Uint64Kind,
 			protoreflect.Fixed32Kind,
 			protoreflect.Fixed64Kind:
-			// TODO: Can we support uint?
-			opts = append(opts, DeclareIdent(currPath, TypeInt))
+			if options.uintAsInt {
+				opts = append(opts, DeclareIdent(currPath, TypeInt))
+			} else {
+				opts = append(opts, DeclareIdent(currPath, TypeUint))
+			}
 		case protoreflect.FloatKind,
 			protoreflect.DoubleKind:
 			opts = append(opts, DeclareIdent(currPath, TypeFloat))
 		case protoreflect.BytesKind:
-			// TODO: Can we support bytes?
-			opts = append(opts, DeclareIdent(currPath, TypeString))
+			if options.bytesAsString {
+				opts = append(opts, DeclareIdent(currPath, TypeString))
+			} else {
+				opts = append(opts, DeclareIdent(currPath, TypeBytes))
+			}
 		case protoreflect.MessageKind:
 			// Special handling for well-known types
 			switch field.Message().FullName() {
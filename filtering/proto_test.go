@@ -5,6 +5,7 @@ import (
 	"time"
 
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"gotest.tools/v3/assert"
 )
@@ -15,11 +16,12 @@ func TestDeclareProtoMessageIdents(t *testing.T) {
 	protoMsg := fullProtobufMessage(t)
 
 	testCases := []struct {
-		name         string
-		opts         []FilterOption
-		filter       string
-		expectedExpr *expr.Expr
-		expectError  bool
+		name             string
+		opts             []FilterOption
+		filter           string
+		expectedExpr     *expr.Expr
+		expectedOverload string
+		expectError      bool
 	}{
 		// String fields
 		{
@@ -110,12 +112,47 @@ func TestDeclareProtoMessageIdents(t *testing.T) {
 			expectError:  false,
 		},
 		{
-			name:         "ok - fixed64 field",
+			name:             "ok - fixed64 field",
+			opts:             []FilterOption{WithFilterableFields("fixed64_field")},
+			filter:           `fixed64_field >= 3000`,
+			expectedExpr:     GreaterEquals(Text("fixed64_field"), Int(3000)),
+			expectedOverload: FunctionOverloadGreaterEqualsUintInt,
+			expectError:      false,
+		},
+		{
+			name:             "ok - fixed64 field declared as int",
+			opts:             []FilterOption{WithFilterableFields("fixed64_field"), WithUintAsInt()},
+			filter:           `fixed64_field >= 3000`,
+			expectedExpr:     GreaterEquals(Text("fixed64_field"), Int(3000)),
+			expectedOverload: FunctionOverloadGreaterEqualsInt,
+			expectError:      false,
+		},
+		{
+			name:        "error - uint64 field declared as int with unsigned literal",
+			opts:        []FilterOption{WithFilterableFields("uint64_field"), WithUintAsInt()},
+			filter:      `uint64_field > 1000u`,
+			expectError: true,
+		},
+		{
+			name:         "ok - uint64 field with unsigned literal above max int64",
+			opts:         []FilterOption{WithFilterableFields("uint64_field")},
+			filter:       `uint64_field > 18446744073709551615u`,
+			expectedExpr: GreaterThan(Text("uint64_field"), Uint(18446744073709551615)),
+			expectError:  false,
+		},
+		{
+			name:         "ok - fixed64 field with unsigned hex literal",
 			opts:         []FilterOption{WithFilterableFields("fixed64_field")},
-			filter:       `fixed64_field >= 3000`,
-			expectedExpr: GreaterEquals(Text("fixed64_field"), Int(3000)),
+			filter:       `fixed64_field = 0xFFFFFFFFFFFFFFFFu`,
+			expectedExpr: Equals(Text("fixed64_field"), Uint(18446744073709551615)),
 			expectError:  false,
 		},
+		{
+			name:        "error - uint32 field with negative literal",
+			opts:        []FilterOption{WithFilterableFields("uint32_field")},
+			filter:      `uint32_field > -1`,
+			expectError: true,
+		},
 		// Float fields
 		{
 			name:         "ok - float field",
@@ -131,6 +168,34 @@ func TestDeclareProtoMessageIdents(t *testing.T) {
 			expectedExpr: LessEquals(Text("double_field"), Float(2.71)),
 			expectError:  false,
 		},
+		// Bytes field
+		{
+			name:         "ok - bytes field",
+			opts:         []FilterOption{WithFilterableFields("bytes_field")},
+			filter:       `bytes_field = bytes("3q2+7w==")`,
+			expectedExpr: Equals(Text("bytes_field"), Bytes([]byte{0xde, 0xad, 0xbe, 0xef})),
+			expectError:  false,
+		},
+		{
+			name:        "error - bytes field compared with string",
+			opts:        []FilterOption{WithFilterableFields("bytes_field")},
+			filter:      `bytes_field = "3q2+7w=="`,
+			expectError: true,
+		},
+		{
+			name:        "error - bytes field with invalid base64",
+			opts:        []FilterOption{WithFilterableFields("bytes_field")},
+			filter:      `bytes_field = bytes("not base64!")`,
+			expectError: true,
+		},
+		{
+			name:             "ok - bytes field declared as string",
+			opts:             []FilterOption{WithFilterableFields("bytes_field"), WithBytesAsString()},
+			filter:           `bytes_field = "3q2+7w=="`,
+			expectedExpr:     Equals(Text("bytes_field"), String("3q2+7w==")),
+			expectedOverload: FunctionOverloadEqualsString,
+			expectError:      false,
+		},
 		// Enum field
 		{
 			name:         "ok - enum field",
@@ -139,6 +204,25 @@ func TestDeclareProtoMessageIdents(t *testing.T) {
 			expectedExpr: Equals(Text("enum_field"), Text("ENUM_VALUE_ONE")),
 			expectError:  false,
 		},
+		{
+			name:         "ok - enum field with number",
+			opts:         []FilterOption{WithFilterableFields("enum_field"), WithEnumNumbers()},
+			filter:       `enum_field = 2`,
+			expectedExpr: Equals(Text("enum_field"), Int(2)),
+			expectError:  false,
+		},
+		{
+			name:        "error - enum field with number not allowed",
+			opts:        []FilterOption{WithFilterableFields("enum_field")},
+			filter:      `enum_field = 2`,
+			expectError: true,
+		},
+		{
+			name:        "error - enum field with unknown value",
+			opts:        []FilterOption{WithFilterableFields("enum_field")},
+			filter:      `enum_field = ENUM_VALUE_THREE`,
+			expectError: true,
+		},
 		// Timestamp field (well-known type)
 		{
 			name:         "ok - timestamp field",
@@ -281,6 +365,34 @@ func TestDeclareProtoMessageIdents(t *testing.T) {
 					protocmp.IgnoreFields(&expr.Expr{}, "id"),
 				)
 			}
+			if tt.expectedOverload != "" {
+				assert.Equal(t, tt.expectedOverload, resolveOverloadID(t, declarations, f.CheckedExpr))
+			}
 		})
 	}
 }
+
+// resolveOverloadID returns the ID of the function overload called by the root expression of the checked expression.
+func resolveOverloadID(t *testing.T, declarations *Declarations, checked *expr.CheckedExpr) string {
+	t.Helper()
+	call := checked.GetExpr().GetCallExpr()
+	function, ok := declarations.LookupFunction(call.GetFunction())
+	assert.Assert(t, ok, "undeclared function: %s", call.GetFunction())
+	for _, overload := range function.GetFunction().GetOverloads() {
+		if len(overload.GetParams()) != len(call.GetArgs()) {
+			continue
+		}
+		match := true
+		for i, param := range overload.GetParams() {
+			if !proto.Equal(param, checked.GetTypeMap()[call.GetArgs()[i].GetId()]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return overload.GetOverloadId()
+		}
+	}
+	t.Fatalf("no matching overload for %s", call.GetFunction())
+	return ""
+}
@@ -11,12 +11,19 @@ func ParseFilter(request Request, declarations *Declarations) (Filter, error) {
 }
 
 // ParseFilter parses and type-checks the provided filter.
+//
+// Arithmetic between operands, such as `now() - duration("24h")`, is only parsed when the declarations declare the
+// `+` or `-` functions, see DeclareTimeArithmetic.
 func ParseFilterString(filter string, declarations *Declarations) (Filter, error) {
 	if filter == "" {
 		return Filter{}, nil
 	}
+	var parserOptions []ParserOption
+	if declarations.declaresArithmetic() {
+		parserOptions = append(parserOptions, WithArithmetic())
+	}
 	var parser Parser
-	parser.Init(filter)
+	parser.Init(filter, parserOptions...)
 	parsedExpr, err := parser.Parse()
 	if err != nil {
 		return Filter{}, err
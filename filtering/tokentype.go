@@ -30,6 +30,7 @@ const (
 	TokenTypeLeftParen     TokenType = "("
 	TokenTypeRightParen    TokenType = ")"
 	TokenTypeMinus         TokenType = "-"
+	TokenTypePlus          TokenType = "+"
 	TokenTypeDot           TokenType = "."
 	TokenTypeEquals        TokenType = "="
 	TokenTypeHas           TokenType = ":"
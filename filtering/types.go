@@ -10,9 +10,11 @@ import (
 //nolint:gochecknoglobals
 var (
 	TypeInt    = &expr.Type{TypeKind: &expr.Type_Primitive{Primitive: expr.Type_INT64}}
+	TypeUint   = &expr.Type{TypeKind: &expr.Type_Primitive{Primitive: expr.Type_UINT64}}
 	TypeFloat  = &expr.Type{TypeKind: &expr.Type_Primitive{Primitive: expr.Type_DOUBLE}}
 	TypeString = &expr.Type{TypeKind: &expr.Type_Primitive{Primitive: expr.Type_STRING}}
 	TypeBool   = &expr.Type{TypeKind: &expr.Type_Primitive{Primitive: expr.Type_BOOL}}
+	TypeBytes  = &expr.Type{TypeKind: &expr.Type_Primitive{Primitive: expr.Type_BYTES}}
 )
 
 // TypeMap returns the type for a map with the provided key and value types.
@@ -2,8 +2,8 @@ package ordering
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
-	"strings"
 	"unicode"
 
 	"go.einride.tech/aip/fieldmask"
@@ -27,12 +27,17 @@ type Field struct {
 
 // SubFields returns the individual subfields of the field path, including the top-level subfield.
 //
-// Subfields are specified with a . character, such as foo.bar or address.street.
+// Subfields are specified with a . character, such as foo.bar or address.street, and may be quoted with backticks.
+// See fieldmask.ParsePath. Returns nil if the field path is not syntactically valid.
 func (f Field) SubFields() []string {
 	if f.Path == "" {
 		return nil
 	}
-	return strings.Split(f.Path, ".")
+	subFields, err := fieldmask.ParsePath(f.Path)
+	if err != nil {
+		return nil
+	}
+	return subFields
 }
 
 // UnmarshalString sets o from the provided ordering string. .
@@ -41,15 +46,29 @@ func (o *OrderBy) UnmarshalString(s string) error {
 	if s == "" { // fast path for no ordering
 		return nil
 	}
+	var quoted bool
 	for _, r := range s {
+		if r == '`' {
+			quoted = !quoted
+			continue
+		}
+		if quoted {
+			continue
+		}
 		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '_' && r != ' ' && r != ',' && r != '.' {
 			return fmt.Errorf("unmarshal order by '%s': invalid character %s", s, strconv.QuoteRune(r))
 		}
 	}
-	fields := strings.Split(s, ",")
+	fields := splitUnquoted(s, func(r rune) bool { return r == ',' })
 	o.Fields = make([]Field, 0, len(fields))
 	for _, field := range fields {
-		parts := strings.Fields(field)
+		parts := splitUnquoted(field, func(r rune) bool { return r == ' ' })
+		parts = slices.DeleteFunc(parts, func(part string) bool { return part == "" })
+		if len(parts) > 0 {
+			if _, err := fieldmask.ParsePath(parts[0]); err != nil {
+				return fmt.Errorf("unmarshal order by '%s': %w", s, err)
+			}
+		}
 		switch len(parts) {
 		case 1: // default ordering (ascending)
 			o.Fields = append(o.Fields, Field{Path: parts[0]})
@@ -75,7 +94,9 @@ func (o *OrderBy) UnmarshalString(s string) error {
 }
 
 // ValidateForMessage validates that the ordering paths are syntactically valid and
-// refer to known fields in the specified message type.
+// refer to known, orderable fields in the specified message type.
+//
+// See ResolveForMessage for which fields are orderable.
 func (o OrderBy) ValidateForMessage(m proto.Message) error {
 	fm := fieldmaskpb.FieldMask{
 		Paths: make([]string, 0, len(o.Fields)),
@@ -83,10 +104,18 @@ func (o OrderBy) ValidateForMessage(m proto.Message) error {
 	for _, field := range o.Fields {
 		fm.Paths = append(fm.Paths, field.Path)
 	}
-	return fieldmask.Validate(&fm, m)
+	if err := fieldmask.Validate(&fm, m); err != nil {
+		return err
+	}
+	_, err := o.ResolveForMessage(m)
+	return err
 }
 
 // ValidateForPaths validates that the ordering paths are syntactically valid and refer to one of the provided paths.
+//
+// Since no message type is provided, ValidateForPaths does not check that the fields are orderable, and the provided
+// paths are trusted to refer to orderable fields. Use ValidateForMessage or ResolveForMessage to also reject
+// repeated, map and message fields.
 func (o OrderBy) ValidateForPaths(paths ...string) error {
 FieldLoop:
 	for _, field := range o.Fields {
@@ -100,3 +129,20 @@ FieldLoop:
 	}
 	return nil
 }
+
+// splitUnquoted splits s around each separator rune that is not quoted with backticks.
+func splitUnquoted(s string, isSeparator func(rune) bool) []string {
+	var result []string
+	var quoted bool
+	var start int
+	for i, r := range s {
+		switch {
+		case r == '`':
+			quoted = !quoted
+		case !quoted && isSeparator(r):
+			result = append(result, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(result, s[start:])
+}
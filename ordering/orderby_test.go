@@ -49,7 +49,28 @@ func TestOrderBy_UnmarshalString(t *testing.T) {
 			},
 		},
 
+		{
+			orderBy: "foo.`bar`, `baz` desc",
+			expected: OrderBy{
+				Fields: []Field{
+					{Path: "foo.`bar`"},
+					{Path: "`baz`", Desc: true},
+				},
+			},
+		},
+
+		{
+			orderBy: "labels.`example.com/a, b` desc",
+			expected: OrderBy{
+				Fields: []Field{
+					{Path: "labels.`example.com/a, b`", Desc: true},
+				},
+			},
+		},
+
 		{orderBy: "foo,", errorContains: "invalid format"},
+		{orderBy: "foo.`bar", errorContains: "unterminated quoted segment"},
+		{orderBy: "foo..bar", errorContains: "empty segment"},
 		{orderBy: ",", errorContains: "invalid "},
 		{orderBy: ",foo", errorContains: "invalid format"},
 		{orderBy: "foo/bar", errorContains: "invalid character '/'"},
@@ -235,6 +256,18 @@ func TestField_SubFields(t *testing.T) {
 			field:    Field{Path: "foo.bar"},
 			expected: []string{"foo", "bar"},
 		},
+
+		{
+			name:     "quoted",
+			field:    Field{Path: "foo.`bar.baz`"},
+			expected: []string{"foo", "bar.baz"},
+		},
+
+		{
+			name:     "invalid",
+			field:    Field{Path: "foo..bar"},
+			expected: nil,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
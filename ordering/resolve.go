@@ -0,0 +1,117 @@
+package ordering
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ResolvedOrderBy is an ordering directive with each field path resolved to field descriptors.
+type ResolvedOrderBy struct {
+	// Fields are the resolved fields to order by.
+	Fields []ResolvedField
+}
+
+// ResolvedField is a single ordering field resolved to field descriptors.
+type ResolvedField struct {
+	Field
+	// Descriptors are the field descriptors of the path, one for each subfield.
+	Descriptors []protoreflect.FieldDescriptor
+}
+
+// Descriptor returns the field descriptor of the last subfield in the path.
+func (f ResolvedField) Descriptor() protoreflect.FieldDescriptor {
+	if len(f.Descriptors) == 0 {
+		return nil
+	}
+	return f.Descriptors[len(f.Descriptors)-1]
+}
+
+// Value returns the value of the field in the message, or false if the field is unset.
+//
+// A field is unset when it has presence and is not populated, or when any of its parent messages are not populated.
+// The message must be of the type the field was resolved for.
+func (f ResolvedField) Value(m proto.Message) (protoreflect.Value, bool) {
+	return fieldValue(f.Descriptors, m.ProtoReflect())
+}
+
+// ResolveForMessage resolves the ordering paths to field descriptors in the specified message type.
+//
+// Each path must refer to a known, orderable field. Orderable fields are singular scalar fields, enum fields and
+// well-known types with a natural ordering, such as google.protobuf.Timestamp and google.protobuf.Duration.
+// Repeated fields, map fields and other message fields are not orderable.
+func (o OrderBy) ResolveForMessage(m proto.Message) (ResolvedOrderBy, error) {
+	result := ResolvedOrderBy{
+		Fields: make([]ResolvedField, 0, len(o.Fields)),
+	}
+	for _, field := range o.Fields {
+		resolved, err := resolveField(field, m.ProtoReflect().Descriptor())
+		if err != nil {
+			return ResolvedOrderBy{}, err
+		}
+		result.Fields = append(result.Fields, resolved)
+	}
+	return result, nil
+}
+
+func resolveField(field Field, md protoreflect.MessageDescriptor) (ResolvedField, error) {
+	subFields := field.SubFields()
+	if len(subFields) == 0 {
+		return ResolvedField{}, fmt.Errorf("invalid field path: %s", field.Path)
+	}
+	result := ResolvedField{
+		Field:       field,
+		Descriptors: make([]protoreflect.FieldDescriptor, 0, len(subFields)),
+	}
+	for i, subField := range subFields {
+		if md == nil {
+			return ResolvedField{}, fmt.Errorf("invalid field path: %s", field.Path)
+		}
+		fd := md.Fields().ByName(protoreflect.Name(subField))
+		if fd == nil {
+			return ResolvedField{}, fmt.Errorf("invalid field path: %s", field.Path)
+		}
+		switch {
+		case fd.IsMap():
+			return ResolvedField{}, fmt.Errorf("field path %s is not orderable: map field %s", field.Path, subField)
+		case fd.IsList():
+			return ResolvedField{}, fmt.Errorf("field path %s is not orderable: repeated field %s", field.Path, subField)
+		}
+		result.Descriptors = append(result.Descriptors, fd)
+		if i == len(subFields)-1 {
+			break
+		}
+		if isOrderableMessage(fd.Message()) {
+			return ResolvedField{}, fmt.Errorf("invalid field path: %s", field.Path)
+		}
+		md = fd.Message() // may be nil
+	}
+	if fd := result.Descriptor(); fd.Message() != nil && !isOrderableMessage(fd.Message()) {
+		return ResolvedField{}, fmt.Errorf("field path %s is not orderable: message field %s", field.Path, fd.Name())
+	}
+	return result, nil
+}
+
+// isOrderableMessage returns true if the message is a well-known type with a natural ordering.
+func isOrderableMessage(md protoreflect.MessageDescriptor) bool {
+	if md == nil {
+		return false
+	}
+	switch md.FullName() {
+	case "google.protobuf.Timestamp",
+		"google.protobuf.Duration",
+		"google.protobuf.DoubleValue",
+		"google.protobuf.FloatValue",
+		"google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value",
+		"google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue",
+		"google.protobuf.StringValue",
+		"google.protobuf.BytesValue":
+		return true
+	default:
+		return false
+	}
+}
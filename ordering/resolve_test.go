@@ -0,0 +1,115 @@
+package ordering
+
+import (
+	"testing"
+
+	freightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+)
+
+func TestOrderBy_ResolveForMessage(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name          string
+		orderBy       string
+		message       proto.Message
+		expected      [][]string
+		errorContains string
+	}{
+		{
+			name:     "empty",
+			orderBy:  "",
+			message:  &freightv1.Shipment{},
+			expected: [][]string{},
+		},
+
+		{
+			name:     "scalar and timestamp",
+			orderBy:  "origin_site, create_time desc",
+			message:  &freightv1.Shipment{},
+			expected: [][]string{{"origin_site"}, {"create_time"}},
+		},
+
+		{
+			name:     "nested",
+			orderBy:  "message.message.string, enum",
+			message:  &syntaxv1.Message{},
+			expected: [][]string{{"message", "message", "string"}, {"enum"}},
+		},
+
+		{
+			name:          "unknown field",
+			orderBy:       "foo",
+			message:       &freightv1.Shipment{},
+			errorContains: "invalid field path: foo",
+		},
+
+		{
+			name:          "repeated field",
+			orderBy:       "line_items",
+			message:       &freightv1.Shipment{},
+			errorContains: "field path line_items is not orderable: repeated field line_items",
+		},
+
+		{
+			name:          "field in repeated field",
+			orderBy:       "line_items.title",
+			message:       &freightv1.Shipment{},
+			errorContains: "field path line_items.title is not orderable: repeated field line_items",
+		},
+
+		{
+			name:          "map field",
+			orderBy:       "annotations",
+			message:       &freightv1.Shipment{},
+			errorContains: "field path annotations is not orderable: map field annotations",
+		},
+
+		{
+			name:          "message field",
+			orderBy:       "message",
+			message:       &syntaxv1.Message{},
+			errorContains: "field path message is not orderable: message field message",
+		},
+
+		{
+			name:          "field in well-known type",
+			orderBy:       "create_time.seconds",
+			message:       &freightv1.Shipment{},
+			errorContains: "invalid field path: create_time.seconds",
+		},
+
+		{
+			name:          "field in scalar",
+			orderBy:       "string.foo",
+			message:       &syntaxv1.Message{},
+			errorContains: "invalid field path: string.foo",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var orderBy OrderBy
+			assert.NilError(t, orderBy.UnmarshalString(tt.orderBy))
+			actual, err := orderBy.ResolveForMessage(tt.message)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.ErrorContains(t, orderBy.ValidateForMessage(tt.message), tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.NilError(t, orderBy.ValidateForMessage(tt.message))
+			assert.Equal(t, len(tt.expected), len(actual.Fields))
+			for i, field := range actual.Fields {
+				assert.Equal(t, orderBy.Fields[i], field.Field)
+				names := make([]string, 0, len(field.Descriptors))
+				for _, fd := range field.Descriptors {
+					names = append(names, string(fd.Name()))
+				}
+				assert.DeepEqual(t, tt.expected[i], names)
+				assert.Equal(t, field.Descriptors[len(field.Descriptors)-1], field.Descriptor())
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package ordering
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Sort sorts the messages in place by the ordering.
+//
+// All messages must be of the same type. The sort is stable, so messages that are equal in ordering keep their
+// original order. See ResolvedOrderBy.Compare for how fields are compared.
+func Sort[T proto.Message](msgs []T, orderBy OrderBy) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	md := msgs[0].ProtoReflect().Descriptor()
+	for _, msg := range msgs[1:] {
+		if msg.ProtoReflect().Descriptor().FullName() != md.FullName() {
+			return fmt.Errorf(
+				"sort: mixed message types %s and %s",
+				md.FullName(),
+				msg.ProtoReflect().Descriptor().FullName(),
+			)
+		}
+	}
+	resolved, err := orderBy.ResolveForMessage(msgs[0])
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(msgs, SortFunc[T](resolved))
+	return nil
+}
+
+// SortFunc returns a comparison function for messages of the type the ordering was resolved for,
+// for use with e.g. slices.SortFunc.
+func SortFunc[T proto.Message](orderBy ResolvedOrderBy) func(x, y T) int {
+	return func(x, y T) int {
+		return orderBy.Compare(x, y)
+	}
+}
+
+// Compare compares the messages x and y field by field by the ordering, and returns
+// -1 if x is ordered before y, +1 if x is ordered after y, and 0 if they are equal in ordering.
+//
+// Scalar fields are compared by value, enum fields by number, and well-known types such as
+// google.protobuf.Timestamp by their natural ordering. Unset fields are null, and nulls are ordered
+// before all other values in ascending order. A field is unset when it has presence and is not populated,
+// or when any of its parent messages are not populated.
+//
+// The messages must be of the type the ordering was resolved for.
+func (o ResolvedOrderBy) Compare(x, y proto.Message) int {
+	for _, field := range o.Fields {
+		result := compareField(field, x.ProtoReflect(), y.ProtoReflect())
+		if field.Desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func compareField(field ResolvedField, x, y protoreflect.Message) int {
+	xValue, xOk := fieldValue(field.Descriptors, x)
+	yValue, yOk := fieldValue(field.Descriptors, y)
+	switch {
+	case !xOk && !yOk:
+		return 0
+	case !xOk:
+		return -1
+	case !yOk:
+		return 1
+	}
+	return compareValues(field.Descriptor(), xValue, yValue)
+}
+
+// fieldValue returns the value of the field at the path, or false if the field is unset.
+func fieldValue(path []protoreflect.FieldDescriptor, m protoreflect.Message) (protoreflect.Value, bool) {
+	for i, fd := range path {
+		if fd.HasPresence() && !m.Has(fd) {
+			return protoreflect.Value{}, false
+		}
+		if i == len(path)-1 {
+			return m.Get(fd), true
+		}
+		m = m.Get(fd).Message()
+	}
+	return protoreflect.Value{}, false
+}
+
+func compareValues(fd protoreflect.FieldDescriptor, x, y protoreflect.Value) int {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		switch {
+		case x.Bool() == y.Bool():
+			return 0
+		case !x.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case protoreflect.EnumKind:
+		return cmp.Compare(x.Enum(), y.Enum())
+	case protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind:
+		return cmp.Compare(x.Int(), y.Int())
+	case protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return cmp.Compare(x.Uint(), y.Uint())
+	case protoreflect.FloatKind,
+		protoreflect.DoubleKind:
+		return cmp.Compare(x.Float(), y.Float())
+	case protoreflect.StringKind:
+		return strings.Compare(x.String(), y.String())
+	case protoreflect.BytesKind:
+		return bytes.Compare(x.Bytes(), y.Bytes())
+	case protoreflect.MessageKind,
+		protoreflect.GroupKind:
+		// Well-known types are ordered by their fields in declaration order,
+		// e.g. seconds and nanos for google.protobuf.Timestamp.
+		xMessage, yMessage := x.Message(), y.Message()
+		fields := fd.Message().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if result := compareValues(field, xMessage.Get(field), yMessage.Get(field)); result != 0 {
+				return result
+			}
+		}
+	}
+	return 0
+}
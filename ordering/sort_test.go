@@ -0,0 +1,171 @@
+package ordering
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	freightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	shipment := func(name string, createTime *timestamppb.Timestamp) *freightv1.Shipment {
+		return &freightv1.Shipment{Name: name, CreateTime: createTime}
+	}
+	message := func(s string, e syntaxv1.Enum, nested string) *syntaxv1.Message {
+		msg := &syntaxv1.Message{String_: s, Enum: e}
+		if nested != "" {
+			msg.Message = &syntaxv1.Message{String_: nested}
+		}
+		return msg
+	}
+	for _, tt := range []struct {
+		name          string
+		orderBy       string
+		msgs          []proto.Message
+		expected      []proto.Message
+		errorContains string
+	}{
+		{
+			name:    "empty",
+			orderBy: "name",
+		},
+
+		{
+			name:    "string asc",
+			orderBy: "name",
+			msgs:    []proto.Message{shipment("b", nil), shipment("c", nil), shipment("a", nil)},
+			expected: []proto.Message{
+				shipment("a", nil),
+				shipment("b", nil),
+				shipment("c", nil),
+			},
+		},
+
+		{
+			name:    "timestamp desc with unset last",
+			orderBy: "create_time desc",
+			msgs: []proto.Message{
+				shipment("a", timestamppb.New(t0)),
+				shipment("b", nil),
+				shipment("c", timestamppb.New(t0.Add(time.Nanosecond))),
+				shipment("d", timestamppb.New(t0.Add(-time.Hour))),
+			},
+			expected: []proto.Message{
+				shipment("c", timestamppb.New(t0.Add(time.Nanosecond))),
+				shipment("a", timestamppb.New(t0)),
+				shipment("d", timestamppb.New(t0.Add(-time.Hour))),
+				shipment("b", nil),
+			},
+		},
+
+		{
+			name:    "timestamp asc with unset first, then name desc",
+			orderBy: "create_time, name desc",
+			msgs: []proto.Message{
+				shipment("a", timestamppb.New(t0)),
+				shipment("b", nil),
+				shipment("c", timestamppb.New(t0)),
+				shipment("d", nil),
+			},
+			expected: []proto.Message{
+				shipment("d", nil),
+				shipment("b", nil),
+				shipment("c", timestamppb.New(t0)),
+				shipment("a", timestamppb.New(t0)),
+			},
+		},
+
+		{
+			name:    "enum then nested with unset parent first",
+			orderBy: "enum desc, message.string",
+			msgs: []proto.Message{
+				message("a", syntaxv1.Enum_ENUM_ONE, "y"),
+				message("b", syntaxv1.Enum_ENUM_TWO, ""),
+				message("c", syntaxv1.Enum_ENUM_ONE, ""),
+				message("d", syntaxv1.Enum_ENUM_ONE, "x"),
+			},
+			expected: []proto.Message{
+				message("b", syntaxv1.Enum_ENUM_TWO, ""),
+				message("c", syntaxv1.Enum_ENUM_ONE, ""),
+				message("d", syntaxv1.Enum_ENUM_ONE, "x"),
+				message("a", syntaxv1.Enum_ENUM_ONE, "y"),
+			},
+		},
+
+		{
+			name:    "stable",
+			orderBy: "enum",
+			msgs: []proto.Message{
+				message("a", syntaxv1.Enum_ENUM_TWO, ""),
+				message("b", syntaxv1.Enum_ENUM_ONE, ""),
+				message("c", syntaxv1.Enum_ENUM_TWO, ""),
+				message("d", syntaxv1.Enum_ENUM_ONE, ""),
+			},
+			expected: []proto.Message{
+				message("b", syntaxv1.Enum_ENUM_ONE, ""),
+				message("d", syntaxv1.Enum_ENUM_ONE, ""),
+				message("a", syntaxv1.Enum_ENUM_TWO, ""),
+				message("c", syntaxv1.Enum_ENUM_TWO, ""),
+			},
+		},
+
+		{
+			name:          "mixed types",
+			orderBy:       "name",
+			msgs:          []proto.Message{shipment("a", nil), &freightv1.Site{Name: "b"}},
+			errorContains: "mixed message types",
+		},
+
+		{
+			name:          "not orderable",
+			orderBy:       "line_items",
+			msgs:          []proto.Message{shipment("a", nil)},
+			errorContains: "not orderable",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var orderBy OrderBy
+			assert.NilError(t, orderBy.UnmarshalString(tt.orderBy))
+			err := Sort(tt.msgs, orderBy)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, tt.msgs, protocmp.Transform())
+		})
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	t.Parallel()
+	var orderBy OrderBy
+	assert.NilError(t, orderBy.UnmarshalString("uint64 desc, bytes"))
+	resolved, err := orderBy.ResolveForMessage(&syntaxv1.Message{})
+	assert.NilError(t, err)
+	msgs := []*syntaxv1.Message{
+		{Uint64: 1, Bytes: []byte("b")},
+		{Uint64: 1 << 63, Bytes: []byte("a")},
+		{Uint64: 1, Bytes: []byte("a")},
+	}
+	slices.SortFunc(msgs, SortFunc[*syntaxv1.Message](resolved))
+	assert.DeepEqual(
+		t,
+		[]*syntaxv1.Message{
+			{Uint64: 1 << 63, Bytes: []byte("a")},
+			{Uint64: 1, Bytes: []byte("a")},
+			{Uint64: 1, Bytes: []byte("b")},
+		},
+		msgs,
+		protocmp.Transform(),
+	)
+}
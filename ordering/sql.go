@@ -0,0 +1,299 @@
+package ordering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.einride.tech/aip/fieldmask"
+)
+
+// Dialect is an SQL dialect.
+type Dialect int
+
+// Supported SQL dialects.
+const (
+	DialectPostgreSQL Dialect = iota + 1
+	DialectMySQL
+	DialectSQLite
+	DialectSpanner
+)
+
+// String implements fmt.Stringer.
+func (d Dialect) String() string {
+	switch d {
+	case DialectPostgreSQL:
+		return "PostgreSQL"
+	case DialectMySQL:
+		return "MySQL"
+	case DialectSQLite:
+		return "SQLite"
+	case DialectSpanner:
+		return "Spanner"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// SQLOption configures the SQL generated from an OrderBy.
+type SQLOption func(*sqlOptions)
+
+type sqlOptions struct {
+	columns         map[string]string
+	tiebreaker      string
+	parameterOffset int
+}
+
+// WithColumns maps field paths to column names.
+//
+// Paths without a mapping use the subfields of the path joined by _ as column name, such as create_time or
+// address_street. The derived column name is always quoted as a single identifier, even when a quoted subfield
+// contains a dot. Only mapped column names may be qualified with a table name, such as shipments.create_time.
+func WithColumns(columns map[string]string) SQLOption {
+	return func(opts *sqlOptions) {
+		opts.columns = columns
+	}
+}
+
+// WithTiebreaker sets the field path of the tiebreaker field, which is ordered by last in ascending order
+// unless already part of the ordering. The tiebreaker field must be unique, so that the ordering is deterministic.
+//
+// The default tiebreaker field is the resource name field, name. Use an empty path to disable the tiebreaker.
+func WithTiebreaker(path string) SQLOption {
+	return func(opts *sqlOptions) {
+		opts.tiebreaker = path
+	}
+}
+
+// WithParameterOffset sets the number of query parameters that precede the parameters of a generated predicate,
+// for dialects with numbered or named parameters. For example, an offset of 2 makes the first parameter $3.
+func WithParameterOffset(offset int) SQLOption {
+	return func(opts *sqlOptions) {
+		opts.parameterOffset = offset
+	}
+}
+
+// SQL returns an ORDER BY clause for the ordering in the provided SQL dialect, such as:
+//
+//	ORDER BY "create_time" DESC NULLS LAST, "name" NULLS FIRST
+//
+// Column names are always quoted. Unset (NULL) values are ordered first in ascending order and last in descending
+// order, consistently across all dialects and with Sort.
+//
+// An empty string is returned when there is nothing to order by.
+func (o OrderBy) SQL(dialect Dialect, opts ...SQLOption) (string, error) {
+	options := newSQLOptions(opts...)
+	fields := o.AppendTiebreaker(options.tiebreaker).Fields
+	if len(fields) == 0 {
+		return "", nil
+	}
+	var result strings.Builder
+	_, _ = result.WriteString("ORDER BY ")
+	for i, field := range fields {
+		if i > 0 {
+			_, _ = result.WriteString(", ")
+		}
+		column, err := options.column(field.Path)
+		if err != nil {
+			return "", err
+		}
+		if err := writeQuotedColumn(&result, dialect, column); err != nil {
+			return "", err
+		}
+		if field.Desc {
+			_, _ = result.WriteString(" DESC")
+		}
+		if dialect == DialectPostgreSQL {
+			// PostgreSQL orders NULL values as larger than any other value by default.
+			if field.Desc {
+				_, _ = result.WriteString(" NULLS LAST")
+			} else {
+				_, _ = result.WriteString(" NULLS FIRST")
+			}
+		}
+	}
+	return result.String(), nil
+}
+
+// SQLKeysetPredicate returns an SQL predicate and its parameters that matches the rows ordered after the row with the
+// provided ordering key values, for keyset (cursor-based) pagination. For example:
+//
+//	("create_time", "name") > ($1, $2)
+//
+// There must be one key for each field of the ordering, including the tiebreaker field. The same options as for SQL
+// must be used, so that the predicate matches the ORDER BY clause. Row value comparisons are used when all fields have
+// the same direction and the dialect supports it, otherwise the comparison is expanded, such as:
+//
+//	(`create_time` < @p1) OR (`create_time` = @p2 AND `name` > @p3)
+//
+// NULL values never compare, so the ordering key columns should not be nullable.
+func (o OrderBy) SQLKeysetPredicate(dialect Dialect, keys []any, opts ...SQLOption) (string, []any, error) {
+	options := newSQLOptions(opts...)
+	fields := o.AppendTiebreaker(options.tiebreaker).Fields
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("sql keyset predicate: no fields to order by")
+	}
+	if len(keys) != len(fields) {
+		return "", nil, fmt.Errorf("sql keyset predicate: got %d keys but expected %d", len(keys), len(fields))
+	}
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		column, err := options.column(field.Path)
+		if err != nil {
+			return "", nil, err
+		}
+		var quoted strings.Builder
+		if err := writeQuotedColumn(&quoted, dialect, column); err != nil {
+			return "", nil, err
+		}
+		columns = append(columns, quoted.String())
+	}
+	var result strings.Builder
+	var params []any
+	parameter := func(key any) string {
+		params = append(params, key)
+		return dialect.parameter(options.parameterOffset + len(params))
+	}
+	if dialect != DialectSpanner && hasSameDirection(fields) {
+		operator := " > "
+		if fields[0].Desc {
+			operator = " < "
+		}
+		_ = result.WriteByte('(')
+		_, _ = result.WriteString(strings.Join(columns, ", "))
+		_ = result.WriteByte(')')
+		_, _ = result.WriteString(operator)
+		_ = result.WriteByte('(')
+		for i, key := range keys {
+			if i > 0 {
+				_, _ = result.WriteString(", ")
+			}
+			_, _ = result.WriteString(parameter(key))
+		}
+		_ = result.WriteByte(')')
+		return result.String(), params, nil
+	}
+	for i, field := range fields {
+		if i > 0 {
+			_, _ = result.WriteString(" OR ")
+		}
+		_ = result.WriteByte('(')
+		for j := 0; j < i; j++ {
+			_, _ = result.WriteString(columns[j])
+			_, _ = result.WriteString(" = ")
+			_, _ = result.WriteString(parameter(keys[j]))
+			_, _ = result.WriteString(" AND ")
+		}
+		_, _ = result.WriteString(columns[i])
+		if field.Desc {
+			_, _ = result.WriteString(" < ")
+		} else {
+			_, _ = result.WriteString(" > ")
+		}
+		_, _ = result.WriteString(parameter(keys[i]))
+		_ = result.WriteByte(')')
+	}
+	return result.String(), params, nil
+}
+
+// AppendTiebreaker returns the ordering with the tiebreaker field appended in ascending order,
+// unless the tiebreaker field is already part of the ordering or the tiebreaker path is empty.
+//
+// The tiebreaker field must be unique, so that the resulting ordering is deterministic.
+func (o OrderBy) AppendTiebreaker(path string) OrderBy {
+	if path == "" || o.hasPath(path) {
+		return o
+	}
+	return OrderBy{
+		Fields: append(o.Fields[:len(o.Fields):len(o.Fields)], Field{Path: path}),
+	}
+}
+
+func (o OrderBy) hasPath(path string) bool {
+	for _, field := range o.Fields {
+		if field.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSameDirection(fields []Field) bool {
+	for _, field := range fields[1:] {
+		if field.Desc != fields[0].Desc {
+			return false
+		}
+	}
+	return true
+}
+
+func newSQLOptions(opts ...SQLOption) sqlOptions {
+	options := sqlOptions{
+		tiebreaker: "name",
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func (d Dialect) parameter(n int) string {
+	switch d {
+	case DialectPostgreSQL:
+		return "$" + strconv.Itoa(n)
+	case DialectSpanner:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// column returns the parts of the possibly qualified column name for the field path.
+func (o *sqlOptions) column(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("sql: empty field path")
+	}
+	if column, ok := o.columns[path]; ok {
+		if column == "" {
+			return nil, fmt.Errorf("sql: empty column for field path %s", path)
+		}
+		parts := strings.Split(column, ".")
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("sql: invalid column %s", column)
+			}
+		}
+		return parts, nil
+	}
+	subFields, err := fieldmask.ParsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("sql: %w", err)
+	}
+	// Quoted subfields may contain dots, so the derived column name must not be split into a qualified name.
+	return []string{strings.Join(subFields, "_")}, nil
+}
+
+func writeQuotedColumn(b *strings.Builder, dialect Dialect, column []string) error {
+	for i, part := range column {
+		if i > 0 {
+			_ = b.WriteByte('.')
+		}
+		switch dialect {
+		case DialectPostgreSQL, DialectSQLite:
+			_ = b.WriteByte('"')
+			_, _ = b.WriteString(strings.ReplaceAll(part, `"`, `""`))
+			_ = b.WriteByte('"')
+		case DialectMySQL:
+			_ = b.WriteByte('`')
+			_, _ = b.WriteString(strings.ReplaceAll(part, "`", "``"))
+			_ = b.WriteByte('`')
+		case DialectSpanner:
+			_ = b.WriteByte('`')
+			_, _ = b.WriteString(strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(part))
+			_ = b.WriteByte('`')
+		default:
+			return fmt.Errorf("sql: unsupported dialect %s", dialect)
+		}
+	}
+	return nil
+}
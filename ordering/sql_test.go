@@ -0,0 +1,252 @@
+package ordering
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOrderBy_SQL(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name          string
+		orderBy       string
+		dialect       Dialect
+		opts          []SQLOption
+		expected      string
+		errorContains string
+	}{
+		{
+			name:     "empty without tiebreaker",
+			orderBy:  "",
+			dialect:  DialectPostgreSQL,
+			opts:     []SQLOption{WithTiebreaker("")},
+			expected: "",
+		},
+
+		{
+			name:     "empty with default tiebreaker",
+			orderBy:  "",
+			dialect:  DialectSpanner,
+			expected: "ORDER BY `name`",
+		},
+
+		{
+			name:     "postgresql",
+			orderBy:  "create_time desc, address.street",
+			dialect:  DialectPostgreSQL,
+			expected: `ORDER BY "create_time" DESC NULLS LAST, "address_street" NULLS FIRST, "name" NULLS FIRST`,
+		},
+
+		{
+			name:     "mysql",
+			orderBy:  "create_time desc, address.street",
+			dialect:  DialectMySQL,
+			expected: "ORDER BY `create_time` DESC, `address_street`, `name`",
+		},
+
+		{
+			name:     "sqlite",
+			orderBy:  "create_time desc, address.street",
+			dialect:  DialectSQLite,
+			expected: `ORDER BY "create_time" DESC, "address_street", "name"`,
+		},
+
+		{
+			name:     "spanner",
+			orderBy:  "create_time desc, address.street",
+			dialect:  DialectSpanner,
+			expected: "ORDER BY `create_time` DESC, `address_street`, `name`",
+		},
+
+		{
+			name:     "tiebreaker already in ordering",
+			orderBy:  "name desc, create_time",
+			dialect:  DialectMySQL,
+			expected: "ORDER BY `name` DESC, `create_time`",
+		},
+
+		{
+			name:    "column mapping and custom tiebreaker",
+			orderBy: "create_time desc",
+			dialect: DialectPostgreSQL,
+			opts: []SQLOption{
+				WithColumns(map[string]string{"create_time": "s.created_at", "id": "s.id"}),
+				WithTiebreaker("id"),
+			},
+			expected: `ORDER BY "s"."created_at" DESC NULLS LAST, "s"."id" NULLS FIRST`,
+		},
+
+		{
+			name:    "quoting postgresql",
+			orderBy: "title",
+			dialect: DialectPostgreSQL,
+			opts: []SQLOption{
+				WithColumns(map[string]string{"title": `ti"tle`}),
+				WithTiebreaker(""),
+			},
+			expected: `ORDER BY "ti""tle" NULLS FIRST`,
+		},
+
+		{
+			name:    "quoting mysql",
+			orderBy: "title",
+			dialect: DialectMySQL,
+			opts: []SQLOption{
+				WithColumns(map[string]string{"title": "ti`tle"}),
+				WithTiebreaker(""),
+			},
+			expected: "ORDER BY `ti``tle`",
+		},
+
+		{
+			name:    "quoting spanner",
+			orderBy: "title",
+			dialect: DialectSpanner,
+			opts: []SQLOption{
+				WithColumns(map[string]string{"title": "ti`t\\le"}),
+				WithTiebreaker(""),
+			},
+			expected: "ORDER BY `ti\\`t\\\\le`",
+		},
+
+		{
+			name:     "quoted subfield with dot is not qualified",
+			orderBy:  "labels.`other_table.secret`",
+			dialect:  DialectPostgreSQL,
+			opts:     []SQLOption{WithTiebreaker("")},
+			expected: `ORDER BY "labels_other_table.secret" NULLS FIRST`,
+		},
+
+		{
+			name:          "invalid column",
+			orderBy:       "title",
+			dialect:       DialectSQLite,
+			opts:          []SQLOption{WithColumns(map[string]string{"title": "s."})},
+			errorContains: "invalid column s.",
+		},
+
+		{
+			name:          "unsupported dialect",
+			orderBy:       "title",
+			dialect:       Dialect(0),
+			errorContains: "unsupported dialect Dialect(0)",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var orderBy OrderBy
+			assert.NilError(t, orderBy.UnmarshalString(tt.orderBy))
+			actual, err := orderBy.SQL(tt.dialect, tt.opts...)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestOrderBy_SQLKeysetPredicate(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name           string
+		orderBy        string
+		dialect        Dialect
+		keys           []any
+		opts           []SQLOption
+		expected       string
+		expectedParams []any
+		errorContains  string
+	}{
+		{
+			name:           "row value postgresql",
+			orderBy:        "create_time",
+			dialect:        DialectPostgreSQL,
+			keys:           []any{int64(1), "shipments/1"},
+			expected:       `("create_time", "name") > ($1, $2)`,
+			expectedParams: []any{int64(1), "shipments/1"},
+		},
+
+		{
+			name:           "row value desc mysql",
+			orderBy:        "create_time desc, name desc",
+			dialect:        DialectMySQL,
+			keys:           []any{int64(1), "shipments/1"},
+			expected:       "(`create_time`, `name`) < (?, ?)",
+			expectedParams: []any{int64(1), "shipments/1"},
+		},
+
+		{
+			name:           "parameter offset",
+			orderBy:        "create_time",
+			dialect:        DialectPostgreSQL,
+			keys:           []any{int64(1), "shipments/1"},
+			opts:           []SQLOption{WithParameterOffset(2)},
+			expected:       `("create_time", "name") > ($3, $4)`,
+			expectedParams: []any{int64(1), "shipments/1"},
+		},
+
+		{
+			name:           "mixed directions sqlite",
+			orderBy:        "create_time desc",
+			dialect:        DialectSQLite,
+			keys:           []any{int64(1), "shipments/1"},
+			expected:       `("create_time" < ?) OR ("create_time" = ? AND "name" > ?)`,
+			expectedParams: []any{int64(1), int64(1), "shipments/1"},
+		},
+
+		{
+			name:           "spanner",
+			orderBy:        "create_time",
+			dialect:        DialectSpanner,
+			keys:           []any{int64(1), "shipments/1"},
+			expected:       "(`create_time` > @p1) OR (`create_time` = @p2 AND `name` > @p3)",
+			expectedParams: []any{int64(1), int64(1), "shipments/1"},
+		},
+
+		{
+			name:    "column mapping without tiebreaker",
+			orderBy: "create_time",
+			dialect: DialectPostgreSQL,
+			keys:    []any{int64(1)},
+			opts: []SQLOption{
+				WithColumns(map[string]string{"create_time": "s.created_at"}),
+				WithTiebreaker(""),
+			},
+			expected:       `("s"."created_at") > ($1)`,
+			expectedParams: []any{int64(1)},
+		},
+
+		{
+			name:          "wrong number of keys",
+			orderBy:       "create_time",
+			dialect:       DialectPostgreSQL,
+			keys:          []any{int64(1)},
+			errorContains: "got 1 keys but expected 2",
+		},
+
+		{
+			name:          "no fields",
+			orderBy:       "",
+			dialect:       DialectPostgreSQL,
+			opts:          []SQLOption{WithTiebreaker("")},
+			errorContains: "no fields to order by",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var orderBy OrderBy
+			assert.NilError(t, orderBy.UnmarshalString(tt.orderBy))
+			actual, params, err := orderBy.SQLKeysetPredicate(tt.dialect, tt.keys, tt.opts...)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, actual)
+			assert.DeepEqual(t, tt.expectedParams, params)
+		})
+	}
+}
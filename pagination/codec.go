@@ -0,0 +1,217 @@
+package pagination
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// TokenCodec encodes the binary representation of page tokens to opaque strings, and decodes them back.
+//
+// Codecs can be used to sign or encrypt page tokens, so that clients can not forge or inspect them.
+type TokenCodec interface {
+	// EncodeToken encodes the binary page token data to a page token string.
+	EncodeToken(data []byte) string
+	// DecodeToken decodes a page token string to binary page token data.
+	DecodeToken(token string) ([]byte, error)
+}
+
+// TokenKey is a secret key used by a TokenCodec to sign or encrypt page tokens.
+type TokenKey struct {
+	// ID of the key, stored in each page token to select the key when decoding.
+	// At most 255 bytes, and should be kept short, since it adds to the length of each page token.
+	ID string
+	// Secret key material.
+	Secret []byte
+}
+
+// base64TokenCodec is the default TokenCodec, which neither signs nor encrypts page tokens.
+type base64TokenCodec struct{}
+
+// EncodeToken implements TokenCodec.
+func (base64TokenCodec) EncodeToken(data []byte) string {
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// DecodeToken implements TokenCodec.
+func (base64TokenCodec) DecodeToken(token string) ([]byte, error) {
+	data, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode token: %w", err)
+	}
+	return data, nil
+}
+
+// NewHMACTokenCodec returns a TokenCodec that signs page tokens with HMAC-SHA256.
+//
+// Page tokens are signed with the first key, and can be decoded with any of the keys. To rotate keys, add the new key
+// first and keep the old keys until all page tokens signed with them have expired.
+//
+// Signed page tokens can not be forged, but their contents can still be read by clients.
+func NewHMACTokenCodec(keys ...TokenKey) (TokenCodec, error) {
+	if err := validateTokenKeys(keys); err != nil {
+		return nil, fmt.Errorf("new HMAC token codec: %w", err)
+	}
+	for _, key := range keys {
+		if len(key.Secret) == 0 {
+			return nil, fmt.Errorf("new HMAC token codec: empty secret for key %q", key.ID)
+		}
+	}
+	return &hmacTokenCodec{keys: keys}, nil
+}
+
+type hmacTokenCodec struct {
+	keys []TokenKey
+}
+
+// EncodeToken implements TokenCodec.
+func (c *hmacTokenCodec) EncodeToken(data []byte) string {
+	key := c.keys[0]
+	message := appendKeyID(make([]byte, 0, 1+len(key.ID)+len(data)+sha256.Size), key.ID)
+	message = append(message, data...)
+	mac := hmac.New(sha256.New, key.Secret)
+	_, _ = mac.Write(message)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(message))
+}
+
+// DecodeToken implements TokenCodec.
+func (c *hmacTokenCodec) DecodeToken(token string) ([]byte, error) {
+	message, err := base64.RawURLEncoding.Strict().DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode token: %w", err)
+	}
+	keyID, rest, err := consumeKeyID(message)
+	if err != nil {
+		return nil, fmt.Errorf("decode token: %w", err)
+	}
+	key, ok := findTokenKey(c.keys, keyID)
+	if !ok {
+		return nil, fmt.Errorf("decode token: unknown key ID %q", keyID)
+	}
+	if len(rest) < sha256.Size {
+		return nil, fmt.Errorf("decode token: invalid signature")
+	}
+	signed, signature := message[:len(message)-sha256.Size], message[len(message)-sha256.Size:]
+	mac := hmac.New(sha256.New, key.Secret)
+	_, _ = mac.Write(signed)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("decode token: invalid signature")
+	}
+	return rest[:len(rest)-sha256.Size], nil
+}
+
+// NewAESGCMTokenCodec returns a TokenCodec that encrypts and authenticates page tokens with AES-GCM.
+//
+// Secrets must be 16, 24 or 32 bytes long, to select AES-128, AES-192 or AES-256. Page tokens are encrypted with the
+// first key, and can be decoded with any of the keys. To rotate keys, add the new key first and keep the old keys
+// until all page tokens encrypted with them have expired.
+func NewAESGCMTokenCodec(keys ...TokenKey) (TokenCodec, error) {
+	if err := validateTokenKeys(keys); err != nil {
+		return nil, fmt.Errorf("new AES-GCM token codec: %w", err)
+	}
+	aeads := make([]cipher.AEAD, 0, len(keys))
+	for _, key := range keys {
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("new AES-GCM token codec: key %q: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("new AES-GCM token codec: key %q: %w", key.ID, err)
+		}
+		aeads = append(aeads, aead)
+	}
+	return &aesGCMTokenCodec{keys: keys, aeads: aeads}, nil
+}
+
+type aesGCMTokenCodec struct {
+	keys  []TokenKey
+	aeads []cipher.AEAD
+}
+
+// EncodeToken implements TokenCodec.
+func (c *aesGCMTokenCodec) EncodeToken(data []byte) string {
+	key, aead := c.keys[0], c.aeads[0]
+	message := appendKeyID(make([]byte, 0, 1+len(key.ID)+aead.NonceSize()+len(data)+aead.Overhead()), key.ID)
+	keyID := message
+	nonce := make([]byte, aead.NonceSize())
+	_, _ = rand.Read(nonce) // never returns an error
+	message = append(message, nonce...)
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(message, nonce, data, keyID))
+}
+
+// DecodeToken implements TokenCodec.
+func (c *aesGCMTokenCodec) DecodeToken(token string) ([]byte, error) {
+	message, err := base64.RawURLEncoding.Strict().DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode token: %w", err)
+	}
+	keyID, rest, err := consumeKeyID(message)
+	if err != nil {
+		return nil, fmt.Errorf("decode token: %w", err)
+	}
+	var aead cipher.AEAD
+	for i, key := range c.keys {
+		if key.ID == keyID {
+			aead = c.aeads[i]
+			break
+		}
+	}
+	if aead == nil {
+		return nil, fmt.Errorf("decode token: unknown key ID %q", keyID)
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("decode token: invalid ciphertext")
+	}
+	nonce, ciphertext := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, message[:len(message)-len(rest)])
+	if err != nil {
+		return nil, fmt.Errorf("decode token: invalid ciphertext")
+	}
+	return data, nil
+}
+
+func validateTokenKeys(keys []TokenKey) error {
+	if len(keys) == 0 {
+		return errors.New("no keys")
+	}
+	ids := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if len(key.ID) > 255 {
+			return fmt.Errorf("key ID %q is longer than 255 bytes", key.ID)
+		}
+		if _, ok := ids[key.ID]; ok {
+			return fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		ids[key.ID] = struct{}{}
+	}
+	return nil
+}
+
+func findTokenKey(keys []TokenKey, id string) (TokenKey, bool) {
+	for _, key := range keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return TokenKey{}, false
+}
+
+// appendKeyID appends the length-prefixed key ID to b.
+func appendKeyID(b []byte, id string) []byte {
+	b = append(b, byte(len(id)))
+	return append(b, id...)
+}
+
+// consumeKeyID parses a length-prefixed key ID from the start of b, and returns the remaining bytes.
+func consumeKeyID(b []byte) (string, []byte, error) {
+	if len(b) == 0 || len(b) < 1+int(b[0]) {
+		return "", nil, errors.New("missing key ID")
+	}
+	return string(b[1 : 1+int(b[0])]), b[1+int(b[0]):], nil
+}
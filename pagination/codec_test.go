@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestTokenCodec(t *testing.T) {
+	t.Parallel()
+	key1 := TokenKey{ID: "1", Secret: []byte("0123456789abcdef")}
+	key2 := TokenKey{ID: "2", Secret: []byte("fedcba9876543210")}
+	for _, tt := range []struct {
+		name     string
+		newCodec func(...TokenKey) (TokenCodec, error)
+	}{
+		{name: "HMAC", newCodec: NewHMACTokenCodec},
+		{name: "AES-GCM", newCodec: NewAESGCMTokenCodec},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			codec, err := tt.newCodec(key1)
+			assert.NilError(t, err)
+			t.Run("round trip", func(t *testing.T) {
+				t.Parallel()
+				for _, data := range []string{"", "foo"} {
+					actual, err := codec.DecodeToken(codec.EncodeToken([]byte(data)))
+					assert.NilError(t, err)
+					assert.Equal(t, data, string(actual))
+				}
+			})
+
+			t.Run("tampered", func(t *testing.T) {
+				t.Parallel()
+				token := []byte(codec.EncodeToken([]byte("foo")))
+				for i := range token {
+					tampered := append([]byte(nil), token...)
+					if tampered[i] == 'A' {
+						tampered[i] = 'B'
+					} else {
+						tampered[i] = 'A'
+					}
+					_, err := codec.DecodeToken(string(tampered))
+					assert.Assert(t, err != nil, "tampered byte %d", i)
+				}
+			})
+
+			t.Run("truncated", func(t *testing.T) {
+				t.Parallel()
+				_, err := codec.DecodeToken("")
+				assert.ErrorContains(t, err, "missing key ID")
+				_, err = codec.DecodeToken("ATE")
+				assert.ErrorContains(t, err, "invalid")
+			})
+
+			t.Run("key rotation", func(t *testing.T) {
+				t.Parallel()
+				rotated, err := tt.newCodec(key2, key1)
+				assert.NilError(t, err)
+				data, err := rotated.DecodeToken(codec.EncodeToken([]byte("foo")))
+				assert.NilError(t, err)
+				assert.Equal(t, "foo", string(data))
+				_, err = codec.DecodeToken(rotated.EncodeToken([]byte("foo")))
+				assert.ErrorContains(t, err, `unknown key ID "2"`)
+			})
+
+			t.Run("wrong secret", func(t *testing.T) {
+				t.Parallel()
+				other, err := tt.newCodec(TokenKey{ID: key1.ID, Secret: key2.Secret})
+				assert.NilError(t, err)
+				_, err = other.DecodeToken(codec.EncodeToken([]byte("foo")))
+				assert.ErrorContains(t, err, "invalid")
+			})
+
+			t.Run("invalid keys", func(t *testing.T) {
+				t.Parallel()
+				_, err := tt.newCodec()
+				assert.ErrorContains(t, err, "no keys")
+				_, err = tt.newCodec(key1, key1)
+				assert.ErrorContains(t, err, `duplicate key ID "1"`)
+				_, err = tt.newCodec(TokenKey{ID: "1"})
+				assert.ErrorContains(t, err, `key "1"`)
+			})
+		})
+	}
+}
+
+func TestParsePageToken_WithTokenCodec(t *testing.T) {
+	t.Parallel()
+	codec, err := NewAESGCMTokenCodec(TokenKey{ID: "1", Secret: []byte("0123456789abcdef")})
+	assert.NilError(t, err)
+	request1 := &library.ListBooksRequest{
+		Parent:   "shelves/1",
+		PageSize: 10,
+	}
+	pageToken1, err := ParsePageToken(request1, WithTokenCodec(codec))
+	assert.NilError(t, err)
+	request2 := &library.ListBooksRequest{
+		Parent:    "shelves/1",
+		PageSize:  10,
+		PageToken: pageToken1.Next(request1).Encode(WithTokenCodec(codec)),
+	}
+	pageToken2, err := ParsePageToken(request2, WithTokenCodec(codec))
+	assert.NilError(t, err)
+	assert.Equal(t, int64(10), pageToken2.Offset)
+	_, err = ParsePageToken(request2)
+	assert.ErrorContains(t, err, "parse offset page token")
+	request3 := &library.ListBooksRequest{
+		Parent:    "shelves/1",
+		PageSize:  10,
+		PageToken: pageToken1.Next(request1).String(),
+	}
+	_, err = ParsePageToken(request3, WithTokenCodec(codec))
+	assert.ErrorContains(t, err, "parse offset page token")
+}
@@ -1,4 +1,13 @@
 // Package pagination provides primitives for implementing AIP pagination.
 //
 // See: https://google.aip.dev/158 (Pagination).
+//
+// # Page token format
+//
+// PageToken and KeysetPageToken are encoded as the binary protobuf serialization of the PageToken message in
+// proto/einride/example/syntax/v1/pagetoken.proto, which is then encoded to a string by the TokenCodec. The default
+// codec uses base64 with the URL-safe alphabet and padding (RFC 4648, section 5).
+//
+// Parsing skips unknown fields, as protobuf does, and rejects page tokens that are not a valid serialization of the
+// message, or a key without a value. Page tokens in the previous gob-based format are still accepted.
 package pagination
@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PageTokenExpiredError is returned when parsing a page token that was issued longer ago than the max age.
+// See WithMaxAge.
+type PageTokenExpiredError struct {
+	// IssueTime is the time the page token was issued. Unix time 0 if not recorded in the page token.
+	IssueTime time.Time
+	// MaxAge is the maximum age of page tokens.
+	MaxAge time.Duration
+}
+
+// Error implements the error interface.
+func (e *PageTokenExpiredError) Error() string {
+	return fmt.Sprintf("page token expired (issued at %s, max age %s)", e.IssueTime.UTC().Format(time.RFC3339), e.MaxAge)
+}
+
+// GRPCStatus converts the error to a gRPC status with code INVALID_ARGUMENT.
+func (e *PageTokenExpiredError) GRPCStatus() *status.Status {
+	return status.Newf(
+		codes.InvalidArgument,
+		"page token expired after %s, restart from the first page without a page token",
+		e.MaxAge,
+	)
+}
+
+// PageTokenVersionError is returned when parsing a page token with a version lower than the minimum version.
+// See WithMinVersion.
+type PageTokenVersionError struct {
+	// Version is the version of the page token.
+	Version uint32
+	// MinVersion is the minimum accepted version of page tokens.
+	MinVersion uint32
+}
+
+// Error implements the error interface.
+func (e *PageTokenVersionError) Error() string {
+	return fmt.Sprintf("page token version %d is older than the minimum version %d", e.Version, e.MinVersion)
+}
+
+// GRPCStatus converts the error to a gRPC status with code INVALID_ARGUMENT.
+func (e *PageTokenVersionError) GRPCStatus() *status.Status {
+	return status.New(
+		codes.InvalidArgument,
+		"page token is no longer supported, restart from the first page without a page token",
+	)
+}
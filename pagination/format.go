@@ -0,0 +1,360 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the page token format.
+const (
+	pageTokenOffsetField          protowire.Number = 1
+	pageTokenRequestChecksumField protowire.Number = 2
+	pageTokenVersionField         protowire.Number = 3
+	pageTokenIssueTimeField       protowire.Number = 4
+	pageTokenKeysField            protowire.Number = 5
+)
+
+// Field numbers of the key format.
+const (
+	keyBoolField      protowire.Number = 1
+	keyIntField       protowire.Number = 2
+	keyUintField      protowire.Number = 3
+	keyDoubleField    protowire.Number = 4
+	keyStringField    protowire.Number = 5
+	keyBytesField     protowire.Number = 6
+	keyTimestampField protowire.Number = 7
+	keyDurationField  protowire.Number = 8
+)
+
+// Field numbers of google.protobuf.Timestamp and google.protobuf.Duration.
+const (
+	secondsField protowire.Number = 1
+	nanosField   protowire.Number = 2
+)
+
+// pageTokenData is the encoded form of page tokens.
+type pageTokenData struct {
+	Offset          int64
+	RequestChecksum uint32
+	Version         uint32
+	IssueTime       int64
+	// Keys are ordering key values, see KeysetPageToken.Keys.
+	Keys []any
+}
+
+// legacyPageTokenData is the gob-encoded form of page tokens before the protobuf-based format.
+type legacyPageTokenData struct {
+	Offset          int64
+	RequestChecksum uint32
+}
+
+func encodePageTokenData(data *pageTokenData, codec TokenCodec) (string, error) {
+	b, err := data.marshal()
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	return codec.EncodeToken(b), nil
+}
+
+func decodePageTokenData(s string, codec TokenCodec) (pageTokenData, error) {
+	b, err := codec.DecodeToken(s)
+	if err != nil {
+		return pageTokenData{}, fmt.Errorf("decode page token: %w", err)
+	}
+	if isLegacyPageTokenData(b) {
+		var legacy legacyPageTokenData
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&legacy); err == nil {
+			return pageTokenData{Offset: legacy.Offset, RequestChecksum: legacy.RequestChecksum}, nil
+		}
+	}
+	var data pageTokenData
+	if err := data.unmarshal(b); err != nil {
+		return pageTokenData{}, fmt.Errorf("decode page token: %w", err)
+	}
+	return data, nil
+}
+
+// isLegacyPageTokenData reports whether b starts like a gob stream of a legacy page token, with a message that defines
+// a struct type: the byte count of the message, the negated id of the type, and the struct field of the definition.
+//
+// The protobuf-based format skips unknown fields, so legacy page tokens can not be detected by failing to parse them.
+func isLegacyPageTokenData(b []byte) bool {
+	_, n := consumeGobUint(b)
+	if n < 0 {
+		return false
+	}
+	b = b[n:]
+	typeID, n := consumeGobUint(b)
+	if n < 0 || typeID&1 == 0 {
+		return false
+	}
+	b = b[n:]
+	return len(b) > 0 && b[0] == gobWireTypeStructField
+}
+
+// gobWireTypeStructField is the field delta of the struct type definition of a gob wire type.
+const gobWireTypeStructField = 0x03
+
+// consumeGobUint parses a gob-encoded unsigned integer, and returns the value and its length, or -1 if invalid.
+func consumeGobUint(b []byte) (uint64, int) {
+	if len(b) == 0 {
+		return 0, -1
+	}
+	if b[0] < 0x80 {
+		return uint64(b[0]), 1
+	}
+	count := -int(int8(b[0]))
+	if count > 8 || len(b) < 1+count {
+		return 0, -1
+	}
+	var x uint64
+	for _, c := range b[1 : 1+count] {
+		x = x<<8 | uint64(c)
+	}
+	return x, 1 + count
+}
+
+func (d *pageTokenData) marshal() ([]byte, error) {
+	var b []byte
+	if d.Offset != 0 {
+		b = protowire.AppendTag(b, pageTokenOffsetField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(d.Offset))
+	}
+	if d.RequestChecksum != 0 {
+		b = protowire.AppendTag(b, pageTokenRequestChecksumField, protowire.Fixed32Type)
+		b = protowire.AppendFixed32(b, d.RequestChecksum)
+	}
+	if d.Version != 0 {
+		b = protowire.AppendTag(b, pageTokenVersionField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(d.Version))
+	}
+	if d.IssueTime != 0 {
+		b = protowire.AppendTag(b, pageTokenIssueTimeField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(d.IssueTime))
+	}
+	for _, key := range d.Keys {
+		keyBytes, err := marshalKey(key)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, pageTokenKeysField, protowire.BytesType)
+		b = protowire.AppendBytes(b, keyBytes)
+	}
+	return b, nil
+}
+
+func (d *pageTokenData) unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == pageTokenOffsetField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			d.Offset, b = int64(v), b[n:]
+		case num == pageTokenRequestChecksumField && typ == protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			d.RequestChecksum, b = v, b[n:]
+		case num == pageTokenVersionField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			d.Version, b = uint32(v), b[n:]
+		case num == pageTokenIssueTimeField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			d.IssueTime, b = int64(v), b[n:]
+		case num == pageTokenKeysField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			key, err := unmarshalKey(v)
+			if err != nil {
+				return err
+			}
+			d.Keys, b = append(d.Keys, key), b[n:]
+		default:
+			// skip unknown fields, as in protobuf
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+func marshalKey(key any) ([]byte, error) {
+	var b []byte
+	switch key := key.(type) {
+	case bool:
+		b = protowire.AppendTag(b, keyBoolField, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(key))
+	case int64:
+		b = protowire.AppendTag(b, keyIntField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(key))
+	case uint64:
+		b = protowire.AppendTag(b, keyUintField, protowire.VarintType)
+		b = protowire.AppendVarint(b, key)
+	case float64:
+		b = protowire.AppendTag(b, keyDoubleField, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(key))
+	case string:
+		b = protowire.AppendTag(b, keyStringField, protowire.BytesType)
+		b = protowire.AppendString(b, key)
+	case []byte:
+		b = protowire.AppendTag(b, keyBytesField, protowire.BytesType)
+		b = protowire.AppendBytes(b, key)
+	case time.Time:
+		b = protowire.AppendTag(b, keyTimestampField, protowire.BytesType)
+		b = protowire.AppendBytes(b, marshalSecondsNanos(key.Unix(), int32(key.Nanosecond())))
+	case time.Duration:
+		b = protowire.AppendTag(b, keyDurationField, protowire.BytesType)
+		b = protowire.AppendBytes(b, marshalSecondsNanos(int64(key/time.Second), int32(key%time.Second)))
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return b, nil
+}
+
+func unmarshalKey(b []byte) (any, error) {
+	var key any
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == keyBoolField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key, b = protowire.DecodeBool(v), b[n:]
+		case num == keyIntField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key, b = int64(v), b[n:]
+		case num == keyUintField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key, b = v, b[n:]
+		case num == keyDoubleField && typ == protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key, b = math.Float64frombits(v), b[n:]
+		case num == keyStringField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key, b = v, b[n:]
+		case num == keyBytesField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			key, b = append([]byte{}, v...), b[n:]
+		case num == keyTimestampField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			seconds, nanos, err := unmarshalSecondsNanos(v)
+			if err != nil {
+				return nil, err
+			}
+			key, b = time.Unix(seconds, int64(nanos)).UTC(), b[n:]
+		case num == keyDurationField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			seconds, nanos, err := unmarshalSecondsNanos(v)
+			if err != nil {
+				return nil, err
+			}
+			key, b = time.Duration(seconds)*time.Second+time.Duration(nanos), b[n:]
+		default:
+			// skip unknown fields, as in protobuf
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	if key == nil {
+		return nil, errors.New("missing key value")
+	}
+	return key, nil
+}
+
+func marshalSecondsNanos(seconds int64, nanos int32) []byte {
+	var b []byte
+	if seconds != 0 {
+		b = protowire.AppendTag(b, secondsField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(seconds))
+	}
+	if nanos != 0 {
+		b = protowire.AppendTag(b, nanosField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(nanos)))
+	}
+	return b
+}
+
+func unmarshalSecondsNanos(b []byte) (seconds int64, nanos int32, _ error) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return 0, 0, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.VarintType || (num != secondsField && num != nanosField) {
+			// skip unknown fields, as in protobuf
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return 0, 0, protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return 0, 0, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num == secondsField {
+			seconds = int64(v)
+		} else {
+			nanos = int32(v)
+		}
+	}
+	return seconds, nanos, nil
+}
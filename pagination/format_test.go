@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+func TestPageTokenData_Format(t *testing.T) {
+	t.Parallel()
+	data := pageTokenData{
+		Offset:          100,
+		RequestChecksum: 0x9acb0442,
+		Version:         2,
+		IssueTime:       1704067200,
+		Keys: []any{
+			true,
+			int64(-1),
+			uint64(math.MaxUint64),
+			0.5,
+			"foo",
+			[]byte("bar"),
+			time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC),
+			90 * time.Minute,
+		},
+	}
+	t.Run("marshal", func(t *testing.T) {
+		t.Parallel()
+		b, err := data.marshal()
+		assert.NilError(t, err)
+		var actual syntaxv1.PageToken
+		assert.NilError(t, proto.Unmarshal(b, &actual))
+		expected := &syntaxv1.PageToken{
+			Offset:          100,
+			RequestChecksum: 0x9acb0442,
+			Version:         2,
+			IssueTime:       1704067200,
+			Keys: []*syntaxv1.PageToken_Key{
+				{Value: &syntaxv1.PageToken_Key_BoolValue{BoolValue: true}},
+				{Value: &syntaxv1.PageToken_Key_IntValue{IntValue: -1}},
+				{Value: &syntaxv1.PageToken_Key_UintValue{UintValue: math.MaxUint64}},
+				{Value: &syntaxv1.PageToken_Key_DoubleValue{DoubleValue: 0.5}},
+				{Value: &syntaxv1.PageToken_Key_StringValue{StringValue: "foo"}},
+				{Value: &syntaxv1.PageToken_Key_BytesValue{BytesValue: []byte("bar")}},
+				{Value: &syntaxv1.PageToken_Key_TimestampValue{
+					TimestampValue: timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC)),
+				}},
+				{Value: &syntaxv1.PageToken_Key_DurationValue{DurationValue: durationpb.New(90 * time.Minute)}},
+			},
+		}
+		assert.DeepEqual(t, expected, &actual, protocmp.Transform())
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		t.Parallel()
+		b, err := data.marshal()
+		assert.NilError(t, err)
+		var msg syntaxv1.PageToken
+		assert.NilError(t, proto.Unmarshal(b, &msg))
+		b, err = proto.MarshalOptions{Deterministic: true}.Marshal(&msg)
+		assert.NilError(t, err)
+		var actual pageTokenData
+		assert.NilError(t, actual.unmarshal(b))
+		assert.DeepEqual(t, data, actual)
+	})
+
+	t.Run("zero keys", func(t *testing.T) {
+		t.Parallel()
+		zero := pageTokenData{Keys: []any{false, int64(0), "", []byte{}, time.Unix(0, 0).UTC(), time.Duration(0)}}
+		b, err := zero.marshal()
+		assert.NilError(t, err)
+		var actual pageTokenData
+		assert.NilError(t, actual.unmarshal(b))
+		assert.DeepEqual(t, zero, actual)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, b := range [][]byte{
+			{0x08},             // truncated varint
+			{0x30},             // truncated unknown field
+			{0x2a, 0x00},       // empty key
+			{0x2a, 0x02, 0x48}, // key with truncated unknown field
+		} {
+			var actual pageTokenData
+			assert.Assert(t, actual.unmarshal(b) != nil, "%x", b)
+		}
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		t.Parallel()
+		for _, b := range [][]byte{
+			{0x08, 0x01, 0x30, 0x01},                         // unknown field
+			{0x08, 0x01, 0x10, 0x01},                         // wrong wire type
+			{0x08, 0x01, 0x2a, 0x04, 0x08, 0x01, 0x48, 0x01}, // key with unknown field
+		} {
+			var actual pageTokenData
+			assert.NilError(t, actual.unmarshal(b), "%x", b)
+			assert.Equal(t, int64(1), actual.Offset)
+		}
+	})
+
+	t.Run("legacy gob format", func(t *testing.T) {
+		t.Parallel()
+		legacy := EncodePageTokenStruct(&PageToken{Offset: 100, RequestChecksum: 0x9acb0442})
+		actual, err := decodePageTokenData(legacy, base64TokenCodec{})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, pageTokenData{Offset: 100, RequestChecksum: 0x9acb0442}, actual)
+		encoded, err := encodePageTokenData(&actual, base64TokenCodec{})
+		assert.NilError(t, err)
+		assert.Assert(t, len(encoded) < len(legacy))
+	})
+}
@@ -0,0 +1,159 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// IterateOption configures Iterate.
+type IterateOption func(*iterateOptions)
+
+type iterateOptions struct {
+	pageSize int32
+	limit    int
+}
+
+// WithPageSize sets the page size of the requests made by Iterate.
+// The default is the page size of the initial request.
+func WithPageSize(pageSize int32) IterateOption {
+	return func(opts *iterateOptions) {
+		opts.pageSize = pageSize
+	}
+}
+
+// WithLimit sets the maximum number of items returned by Iterate.
+// No more pages are requested once the limit is reached. The default is no limit.
+func WithLimit(limit int) IterateOption {
+	return func(opts *iterateOptions) {
+		opts.limit = limit
+	}
+}
+
+// Iterate returns an iterator over all items of a paginated method, such as a List method.
+//
+// The iterator calls fn with a copy of the request for each page, with the page token set to the next page token of
+// the previous response, until a response has no next page token. The items of each page are read from the repeated
+// field of the response with element type Item, and the next page token from the next_page_token field. When Item is
+// *dynamicpb.Message, the response must have a single repeated message field.
+//
+// When fn fails, or the response does not have the expected fields, the error is yielded and the iteration stops.
+//
+// Example:
+//
+//	for shelf, err := range pagination.Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+//		ctx,
+//		&library.ListShelvesRequest{},
+//		func(ctx context.Context, request *library.ListShelvesRequest) (*library.ListShelvesResponse, error) {
+//			return client.ListShelves(ctx, request)
+//		},
+//	) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(shelf.GetName())
+//	}
+func Iterate[Req Request, Resp proto.Message, Item proto.Message](
+	ctx context.Context,
+	request Req,
+	fn func(context.Context, Req) (Resp, error),
+	opts ...IterateOption,
+) iter.Seq2[Item, error] {
+	var options iterateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return func(yield func(Item, error) bool) {
+		var zero Item
+		request := proto.Clone(request).(Req)
+		requestMessage := request.ProtoReflect()
+		pageTokenField, err := findStringField(requestMessage.Descriptor(), "page_token")
+		if err != nil {
+			yield(zero, fmt.Errorf("iterate: %w", err))
+			return
+		}
+		if options.pageSize > 0 {
+			pageSizeField := requestMessage.Descriptor().Fields().ByName("page_size")
+			if pageSizeField == nil || pageSizeField.Kind() != protoreflect.Int32Kind {
+				yield(zero, fmt.Errorf("iterate: no int32 field page_size in %s", requestMessage.Descriptor().FullName()))
+				return
+			}
+			requestMessage.Set(pageSizeField, protoreflect.ValueOfInt32(options.pageSize))
+		}
+		var count int
+		for {
+			response, err := fn(ctx, request)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			responseMessage := response.ProtoReflect()
+			itemsField, err := findItemsField[Item](responseMessage)
+			if err != nil {
+				yield(zero, fmt.Errorf("iterate: %w", err))
+				return
+			}
+			nextPageTokenField, err := findStringField(responseMessage.Descriptor(), "next_page_token")
+			if err != nil {
+				yield(zero, fmt.Errorf("iterate: %w", err))
+				return
+			}
+			items := responseMessage.Get(itemsField).List()
+			for i := 0; i < items.Len(); i++ {
+				if options.limit > 0 && count >= options.limit {
+					return
+				}
+				count++
+				if !yield(items.Get(i).Message().Interface().(Item), nil) {
+					return
+				}
+			}
+			nextPageToken := responseMessage.Get(nextPageTokenField).String()
+			if nextPageToken == "" || (options.limit > 0 && count >= options.limit) {
+				return
+			}
+			if nextPageToken == request.GetPageToken() {
+				yield(zero, fmt.Errorf("iterate: next page token is the same as the current page token"))
+				return
+			}
+			requestMessage.Set(pageTokenField, protoreflect.ValueOfString(nextPageToken))
+		}
+	}
+}
+
+func findStringField(md protoreflect.MessageDescriptor, name protoreflect.Name) (protoreflect.FieldDescriptor, error) {
+	fd := md.Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.Cardinality() == protoreflect.Repeated {
+		return nil, fmt.Errorf("no string field %s in %s", name, md.FullName())
+	}
+	return fd, nil
+}
+
+// findItemsField returns the repeated field of the response with elements of type Item.
+//
+// Fields are matched by the Go type of their elements, rather than by the descriptor of Item, since the zero value of
+// Item may not have a descriptor, such as a nil *dynamicpb.Message.
+func findItemsField[Item proto.Message](response protoreflect.Message) (protoreflect.FieldDescriptor, error) {
+	md := response.Descriptor()
+	var result protoreflect.FieldDescriptor
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if !fd.IsList() || fd.Message() == nil {
+			continue
+		}
+		if _, ok := response.NewField(fd).List().NewElement().Message().Interface().(Item); !ok {
+			continue
+		}
+		if result != nil {
+			return nil, fmt.Errorf("multiple repeated %T fields in %s", *new(Item), md.FullName())
+		}
+		result = fd
+	}
+	if result == nil {
+		return nil, fmt.Errorf("no repeated %T field in %s", *new(Item), md.FullName())
+	}
+	return result, nil
+}
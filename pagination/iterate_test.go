@@ -0,0 +1,169 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestIterate(t *testing.T) {
+	t.Parallel()
+	shelves := make([]*library.Shelf, 0, 10)
+	for i := range 10 {
+		shelves = append(shelves, &library.Shelf{Name: fmt.Sprintf("shelves/%d", i)})
+	}
+	type call struct {
+		PageSize  int32
+		PageToken bool
+	}
+	listShelves := func(calls *[]call) func(
+		context.Context,
+		*library.ListShelvesRequest,
+	) (*library.ListShelvesResponse, error) {
+		return func(_ context.Context, request *library.ListShelvesRequest) (*library.ListShelvesResponse, error) {
+			*calls = append(*calls, call{PageSize: request.GetPageSize(), PageToken: request.GetPageToken() != ""})
+			pageToken, err := ParsePageToken(request, WithPageSizePolicy(PageSizePolicy{Default: 4}))
+			if err != nil {
+				return nil, err
+			}
+			end := min(pageToken.Offset+int64(pageToken.PageSize), int64(len(shelves)))
+			response := &library.ListShelvesResponse{Shelves: shelves[pageToken.Offset:end]}
+			if end < int64(len(shelves)) {
+				response.NextPageToken = pageToken.Next(request).String()
+			}
+			return response, nil
+		}
+	}
+	names := func(seq func(yield func(*library.Shelf, error) bool)) ([]string, error) {
+		var result []string
+		for shelf, err := range seq {
+			if err != nil {
+				return result, err
+			}
+			result = append(result, shelf.GetName())
+		}
+		return result, nil
+	}
+	t.Run("all pages", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		request := &library.ListShelvesRequest{}
+		actual, err := names(Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+			context.Background(), request, listShelves(&calls),
+		))
+		assert.NilError(t, err)
+		assert.Equal(t, 10, len(actual))
+		assert.Equal(t, "shelves/9", actual[9])
+		assert.DeepEqual(t, []call{{0, false}, {0, true}, {0, true}}, calls)
+		assert.Equal(t, "", request.GetPageToken()) // request is not modified
+	})
+
+	t.Run("page size and limit", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		actual, err := names(Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+			context.Background(),
+			&library.ListShelvesRequest{},
+			listShelves(&calls),
+			WithPageSize(3),
+			WithLimit(6),
+		))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []string{"shelves/0", "shelves/1", "shelves/2", "shelves/3", "shelves/4", "shelves/5"}, actual)
+		assert.DeepEqual(t, []call{{3, false}, {3, true}}, calls)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		for shelf, err := range Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+			context.Background(), &library.ListShelvesRequest{}, listShelves(&calls),
+		) {
+			assert.NilError(t, err)
+			if shelf.GetName() == "shelves/5" {
+				break
+			}
+		}
+		assert.Equal(t, 2, len(calls))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		_, err := names(Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+			context.Background(), &library.ListShelvesRequest{PageSize: -1}, listShelves(&calls),
+		))
+		assert.ErrorContains(t, err, "page_size")
+	})
+
+	t.Run("error on later page", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		next := listShelves(&calls)
+		actual, err := names(Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+			context.Background(),
+			&library.ListShelvesRequest{},
+			func(ctx context.Context, request *library.ListShelvesRequest) (*library.ListShelvesResponse, error) {
+				if request.GetPageToken() != "" {
+					return nil, errors.New("boom")
+				}
+				return next(ctx, request)
+			},
+		))
+		assert.Error(t, err, "boom")
+		assert.Equal(t, 4, len(actual))
+	})
+
+	t.Run("wrong item type", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		for _, err := range Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Book](
+			context.Background(), &library.ListShelvesRequest{}, listShelves(&calls),
+		) {
+			assert.ErrorContains(t, err, "no repeated *library.Book field in google.example.library.v1.ListShelvesResponse")
+		}
+	})
+
+	t.Run("dynamic messages", func(t *testing.T) {
+		t.Parallel()
+		var calls []call
+		next := listShelves(&calls)
+		var actual []string
+		for shelf, err := range Iterate[*library.ListShelvesRequest, *dynamicpb.Message, *dynamicpb.Message](
+			context.Background(),
+			&library.ListShelvesRequest{},
+			func(ctx context.Context, request *library.ListShelvesRequest) (*dynamicpb.Message, error) {
+				response, err := next(ctx, request)
+				if err != nil {
+					return nil, err
+				}
+				result := dynamicpb.NewMessage(response.ProtoReflect().Descriptor())
+				proto.Merge(result, response)
+				return result, nil
+			},
+		) {
+			assert.NilError(t, err)
+			actual = append(actual, shelf.Get(shelf.Descriptor().Fields().ByName("name")).String())
+		}
+		assert.Equal(t, 10, len(actual))
+		assert.Equal(t, "shelves/9", actual[9])
+	})
+
+	t.Run("same page token", func(t *testing.T) {
+		t.Parallel()
+		_, err := names(Iterate[*library.ListShelvesRequest, *library.ListShelvesResponse, *library.Shelf](
+			context.Background(),
+			&library.ListShelvesRequest{PageToken: "foo"},
+			func(context.Context, *library.ListShelvesRequest) (*library.ListShelvesResponse, error) {
+				return &library.ListShelvesResponse{NextPageToken: "foo"}, nil
+			},
+		))
+		assert.ErrorContains(t, err, "same as the current page token")
+	})
+}
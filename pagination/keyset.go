@@ -0,0 +1,272 @@
+package pagination
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// KeysetPageToken is a page token that uses the ordering key values of the last item of the previous page to
+// delineate which page to fetch, also known as cursor-based pagination.
+//
+// Unlike offset-based page tokens, keyset page tokens are stable when items are inserted or deleted between calls,
+// and can be served efficiently by an index on the ordering fields.
+//
+// The ordering must be deterministic, so it must end with a unique field such as the resource name.
+// See ordering.OrderBy.AppendTiebreaker.
+type KeysetPageToken struct {
+	// Keys are the ordering key values of the last item of the previous page, one per ordering field.
+	// Keys are empty for the first page.
+	//
+	// Supported key types are bool, int64 (also for enums), uint64, float64, string, []byte, time.Time and
+	// time.Duration. Encoding a page token with keys of other types fails.
+	Keys []any
+	// RequestChecksum is the checksum of the request that generated the page token.
+	RequestChecksum uint32
+	// PageSize is the effective page size of the request the page token was parsed from.
+	// Only set when parsed with a page size policy, see WithPageSizePolicy.
+	PageSize int32
+}
+
+// keysetPageTokenChecksumMask is a random bitmask applied to keyset-based page token checksums.
+//
+// Change the bitmask to force checksum failures when changing the page token implementation.
+const keysetPageTokenChecksumMask uint32 = 0x5f3c8d21
+
+// ParseKeysetPageToken parses a keyset-based page token from the provided Request.
+//
+// If the request does not have a page token, a page token without keys will be returned.
+//
+// The options must match the options used to encode the page token, see KeysetPageToken.Encode. Parsing fails with a
+// *PageTokenVersionError or *PageTokenExpiredError when the page token is too old, see WithMinVersion and WithMaxAge.
+func ParseKeysetPageToken(request Request, opts ...PageTokenOption) (_ KeysetPageToken, err error) {
+	options := newPageTokenOptions(opts...)
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("parse keyset page token: %w", err)
+		}
+	}()
+	pageSize, err := options.effectivePageSize(request)
+	if err != nil {
+		return KeysetPageToken{}, err
+	}
+	requestChecksum, err := CalculateRequestChecksum(request, options.checksum...)
+	if err != nil {
+		return KeysetPageToken{}, err
+	}
+	requestChecksum ^= keysetPageTokenChecksumMask // apply checksum mask for KeysetPageToken
+	if request.GetPageToken() == "" {
+		return KeysetPageToken{
+			RequestChecksum: requestChecksum,
+			PageSize:        pageSize,
+		}, nil
+	}
+	data, err := decodePageTokenData(request.GetPageToken(), options.codec)
+	if err != nil {
+		return KeysetPageToken{}, err
+	}
+	if err := options.checkIssued(data.Version, data.IssueTime); err != nil {
+		return KeysetPageToken{}, err
+	}
+	if data.RequestChecksum != requestChecksum {
+		return KeysetPageToken{}, fmt.Errorf(
+			"checksum mismatch (got 0x%x but expected 0x%x)", data.RequestChecksum, requestChecksum,
+		)
+	}
+	return KeysetPageToken{
+		Keys:            data.Keys,
+		RequestChecksum: data.RequestChecksum,
+		PageSize:        pageSize,
+	}, nil
+}
+
+// Next returns the next page token, with the ordering key values of the last item of the current page.
+//
+// The ordering must be resolved for the type of the last item, and include the tiebreaker field.
+// All ordering fields of the last item must be set.
+func (p KeysetPageToken) Next(orderBy ordering.ResolvedOrderBy, last proto.Message) (KeysetPageToken, error) {
+	keys := make([]any, 0, len(orderBy.Fields))
+	for _, field := range orderBy.Fields {
+		value, ok := field.Value(last)
+		if !ok {
+			return KeysetPageToken{}, fmt.Errorf("next keyset page token: unset ordering field %s", field.Path)
+		}
+		key, err := keyValue(field.Descriptor(), value)
+		if err != nil {
+			return KeysetPageToken{}, fmt.Errorf("next keyset page token: field %s: %w", field.Path, err)
+		}
+		keys = append(keys, key)
+	}
+	p.Keys = keys
+	return p, nil
+}
+
+// SQLPredicate returns an SQL predicate and its parameters that matches the items after the previous page.
+//
+// The ordering and options must be the same as for the ORDER BY clause, see ordering.OrderBy.SQLKeysetPredicate.
+// An empty predicate is returned for the first page.
+func (p KeysetPageToken) SQLPredicate(
+	orderBy ordering.OrderBy,
+	dialect ordering.Dialect,
+	opts ...ordering.SQLOption,
+) (string, []any, error) {
+	if len(p.Keys) == 0 {
+		return "", nil, nil
+	}
+	return orderBy.SQLKeysetPredicate(dialect, p.Keys, opts...)
+}
+
+// Filter returns a filter expression that matches the items after the previous page, such as:
+//
+//	create_time > timestamp("2024-01-01T00:00:00Z") OR (create_time = timestamp("2024-01-01T00:00:00Z") AND name > "a")
+//
+// The ordering must be the same as for the Next page token. A nil expression is returned for the first page.
+func (p KeysetPageToken) Filter(orderBy ordering.ResolvedOrderBy) (*expr.Expr, error) {
+	if len(p.Keys) == 0 {
+		return nil, nil
+	}
+	if len(p.Keys) != len(orderBy.Fields) {
+		return nil, fmt.Errorf("keyset filter: got %d keys but expected %d", len(p.Keys), len(orderBy.Fields))
+	}
+	fields := make([]*expr.Expr, 0, len(orderBy.Fields))
+	values := make([]*expr.Expr, 0, len(orderBy.Fields))
+	for i, field := range orderBy.Fields {
+		value, err := keyExpr(field.Descriptor(), p.Keys[i])
+		if err != nil {
+			return nil, fmt.Errorf("keyset filter: field %s: %w", field.Path, err)
+		}
+		fields = append(fields, fieldExpr(field))
+		values = append(values, value)
+	}
+	alternatives := make([]*expr.Expr, 0, len(fields))
+	for i, field := range orderBy.Fields {
+		var comparison *expr.Expr
+		if field.Desc {
+			comparison = filtering.LessThan(fields[i], values[i])
+		} else {
+			comparison = filtering.GreaterThan(fields[i], values[i])
+		}
+		if i == 0 {
+			alternatives = append(alternatives, comparison)
+			continue
+		}
+		conditions := make([]*expr.Expr, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, filtering.Equals(fields[j], values[j]))
+		}
+		alternatives = append(alternatives, filtering.And(append(conditions, comparison)...))
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return filtering.Or(alternatives...), nil
+}
+
+// Encode returns a string representation of the page token, encoded with the provided options.
+//
+// The page token records the version and the current time as its issue time. Returns an error if any of the keys
+// is of an unsupported type, see Keys.
+func (p KeysetPageToken) Encode(opts ...PageTokenOption) (string, error) {
+	options := newPageTokenOptions(opts...)
+	return encodePageTokenData(&pageTokenData{
+		Keys:            p.Keys,
+		RequestChecksum: p.RequestChecksum,
+		Version:         options.version,
+		IssueTime:       options.issueTime(),
+	}, options.codec)
+}
+
+// keyValue returns the ordering key value of a field value.
+func keyValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return value.Bool(), nil
+	case protoreflect.EnumKind:
+		return int64(value.Enum()), nil
+	case protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind:
+		return value.Int(), nil
+	case protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return value.Uint(), nil
+	case protoreflect.FloatKind,
+		protoreflect.DoubleKind:
+		return value.Float(), nil
+	case protoreflect.StringKind:
+		return value.String(), nil
+	case protoreflect.BytesKind:
+		return bytes.Clone(value.Bytes()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := value.Message()
+		fields := m.Descriptor().Fields()
+		switch m.Descriptor().FullName() {
+		case "google.protobuf.Timestamp":
+			seconds, nanos := m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()
+			return time.Unix(seconds, nanos).UTC(), nil
+		case "google.protobuf.Duration":
+			seconds, nanos := m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()
+			return time.Duration(seconds)*time.Second + time.Duration(nanos), nil
+		default:
+			// Wrapper types.
+			valueField := fields.ByName("value")
+			if valueField != nil && m.Descriptor().ParentFile().Package() == "google.protobuf" {
+				return keyValue(valueField, m.Get(valueField))
+			}
+		}
+		return nil, fmt.Errorf("unsupported message type %s", m.Descriptor().FullName())
+	}
+	return nil, fmt.Errorf("unsupported kind %s", fd.Kind())
+}
+
+// keyExpr returns a filter expression constant for an ordering key value.
+func keyExpr(fd protoreflect.FieldDescriptor, key any) (*expr.Expr, error) {
+	switch key := key.(type) {
+	case bool:
+		return filtering.Bool(key), nil
+	case int64:
+		if fd.Kind() == protoreflect.EnumKind {
+			if value := fd.Enum().Values().ByNumber(protoreflect.EnumNumber(key)); value != nil {
+				return filtering.Text(string(value.Name())), nil
+			}
+		}
+		return filtering.Int(key), nil
+	case uint64:
+		return filtering.Uint(key), nil
+	case float64:
+		return filtering.Float(key), nil
+	case string:
+		return filtering.String(key), nil
+	case []byte:
+		return filtering.Bytes(key), nil
+	case time.Time:
+		return filtering.Function(filtering.FunctionTimestamp, filtering.String(key.Format(time.RFC3339Nano))), nil
+	case time.Duration:
+		return filtering.Duration(key), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+func fieldExpr(field ordering.ResolvedField) *expr.Expr {
+	var result *expr.Expr
+	for _, fd := range field.Descriptors {
+		if result == nil {
+			result = filtering.Text(string(fd.Name()))
+			continue
+		}
+		result = filtering.Member(result, string(fd.Name()))
+	}
+	return result
+}
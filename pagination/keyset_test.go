@@ -0,0 +1,208 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	freightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseKeysetPageToken(t *testing.T) {
+	t.Parallel()
+	createTime := time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC)
+	var orderBy ordering.OrderBy
+	assert.NilError(t, orderBy.UnmarshalString("create_time desc"))
+	orderBy = orderBy.AppendTiebreaker("name")
+	resolved, err := orderBy.ResolveForMessage(&freightv1.Shipment{})
+	assert.NilError(t, err)
+	t.Run("valid checksums", func(t *testing.T) {
+		t.Parallel()
+		request1 := &freightv1.ListShipmentsRequest{
+			Parent:   "shippers/1",
+			PageSize: 10,
+		}
+		pageToken1, err := ParseKeysetPageToken(request1)
+		assert.NilError(t, err)
+		assert.Equal(t, 0, len(pageToken1.Keys))
+		predicate, params, err := pageToken1.SQLPredicate(orderBy, ordering.DialectPostgreSQL)
+		assert.NilError(t, err)
+		assert.Equal(t, "", predicate)
+		assert.Equal(t, 0, len(params))
+		nextPageToken1, err := pageToken1.Next(resolved, &freightv1.Shipment{
+			Name:       "shippers/1/shipments/1",
+			CreateTime: timestamppb.New(createTime),
+		})
+		assert.NilError(t, err)
+		encoded, err := nextPageToken1.Encode()
+		assert.NilError(t, err)
+		request2 := &freightv1.ListShipmentsRequest{
+			Parent:    "shippers/1",
+			PageSize:  20,
+			PageToken: encoded,
+		}
+		pageToken2, err := ParseKeysetPageToken(request2)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []any{createTime, "shippers/1/shipments/1"}, pageToken2.Keys)
+		predicate, params, err = pageToken2.SQLPredicate(orderBy, ordering.DialectPostgreSQL)
+		assert.NilError(t, err)
+		assert.Equal(t, `("create_time" < $1) OR ("create_time" = $2 AND "name" > $3)`, predicate)
+		assert.DeepEqual(t, []any{createTime, createTime, "shippers/1/shipments/1"}, params)
+	})
+
+	t.Run("invalid checksum", func(t *testing.T) {
+		t.Parallel()
+		request1 := &freightv1.ListShipmentsRequest{
+			Parent:   "shippers/1",
+			PageSize: 10,
+		}
+		pageToken1, err := ParseKeysetPageToken(request1)
+		assert.NilError(t, err)
+		nextPageToken1, err := pageToken1.Next(resolved, &freightv1.Shipment{
+			Name:       "shippers/1/shipments/1",
+			CreateTime: timestamppb.New(createTime),
+		})
+		assert.NilError(t, err)
+		encoded, err := nextPageToken1.Encode()
+		assert.NilError(t, err)
+		request2 := &freightv1.ListShipmentsRequest{
+			Parent:    "shippers/2", // not the same parent as in the first request
+			PageSize:  20,
+			PageToken: encoded,
+		}
+		pageToken2, err := ParseKeysetPageToken(request2)
+		assert.ErrorContains(t, err, "checksum")
+		assert.DeepEqual(t, KeysetPageToken{}, pageToken2)
+	})
+
+	t.Run("offset page token", func(t *testing.T) {
+		t.Parallel()
+		request1 := &freightv1.ListShipmentsRequest{
+			Parent:   "shippers/1",
+			PageSize: 10,
+		}
+		pageToken1, err := ParsePageToken(request1)
+		assert.NilError(t, err)
+		request2 := &freightv1.ListShipmentsRequest{
+			Parent:    "shippers/1",
+			PageSize:  10,
+			PageToken: pageToken1.Next(request1).String(),
+		}
+		_, err = ParseKeysetPageToken(request2)
+		assert.ErrorContains(t, err, "parse keyset page token")
+	})
+
+	t.Run("unset ordering field", func(t *testing.T) {
+		t.Parallel()
+		pageToken, err := ParseKeysetPageToken(&freightv1.ListShipmentsRequest{Parent: "shippers/1"})
+		assert.NilError(t, err)
+		_, err = pageToken.Next(resolved, &freightv1.Shipment{Name: "shippers/1/shipments/1"})
+		assert.ErrorContains(t, err, "unset ordering field create_time")
+	})
+}
+
+func TestKeysetPageToken_Keys(t *testing.T) {
+	t.Parallel()
+	var orderBy ordering.OrderBy
+	assert.NilError(t, orderBy.UnmarshalString(
+		"bool, enum, int32, uint64, double, string, bytes, message.int64",
+	))
+	resolved, err := orderBy.ResolveForMessage(&syntaxv1.Message{})
+	assert.NilError(t, err)
+	pageToken, err := ParseKeysetPageToken(&freightv1.ListShipmentsRequest{Parent: "shippers/1"})
+	assert.NilError(t, err)
+	pageToken, err = pageToken.Next(resolved, &syntaxv1.Message{
+		Bool:    true,
+		Enum:    syntaxv1.Enum_ENUM_TWO,
+		Int32:   -1,
+		Uint64:  1 << 63,
+		Double:  0.5,
+		String_: "foo",
+		Bytes:   []byte("bar"),
+		Message: &syntaxv1.Message{Int64: 42},
+	})
+	assert.NilError(t, err)
+	expected := []any{true, int64(2), int64(-1), uint64(1 << 63), 0.5, "foo", []byte("bar"), int64(42)}
+	assert.DeepEqual(t, expected, pageToken.Keys)
+	encoded, err := pageToken.Encode()
+	assert.NilError(t, err)
+	parsed, err := ParseKeysetPageToken(&freightv1.ListShipmentsRequest{
+		Parent:    "shippers/1",
+		PageToken: encoded,
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, expected, parsed.Keys)
+}
+
+func TestKeysetPageToken_Encode(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name          string
+		keys          []any
+		errorContains string
+	}{
+		{
+			name: "no keys",
+		},
+		{
+			name: "supported keys",
+			keys: []any{true, int64(1), uint64(1), 0.5, "foo", []byte("bar"), time.Unix(1, 2), time.Second},
+		},
+		{
+			name:          "int key",
+			keys:          []any{"foo", 1},
+			errorContains: "unsupported key type int",
+		},
+		{
+			name:          "custom key",
+			keys:          []any{struct{}{}},
+			errorContains: "unsupported key type struct {}",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			encoded, err := KeysetPageToken{Keys: tt.keys}.Encode()
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, encoded != "")
+		})
+	}
+}
+
+func TestKeysetPageToken_Filter(t *testing.T) {
+	t.Parallel()
+	createTime := time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC)
+	var orderBy ordering.OrderBy
+	assert.NilError(t, orderBy.UnmarshalString("create_time desc, name"))
+	resolved, err := orderBy.ResolveForMessage(&freightv1.Shipment{})
+	assert.NilError(t, err)
+	pageToken := KeysetPageToken{Keys: []any{createTime, "shippers/1/shipments/1"}}
+	actual, err := pageToken.Filter(resolved)
+	assert.NilError(t, err)
+	timestamp := filtering.Function(filtering.FunctionTimestamp, filtering.String("2024-01-01T00:00:00.000000001Z"))
+	assert.DeepEqual(
+		t,
+		filtering.Or(
+			filtering.LessThan(filtering.Text("create_time"), timestamp),
+			filtering.And(
+				filtering.Equals(filtering.Text("create_time"), timestamp),
+				filtering.GreaterThan(filtering.Text("name"), filtering.String("shippers/1/shipments/1")),
+			),
+		),
+		actual,
+		protocmp.Transform(),
+	)
+	_, err = KeysetPageToken{Keys: []any{createTime}}.Filter(resolved)
+	assert.ErrorContains(t, err, "got 1 keys but expected 2")
+	first, err := KeysetPageToken{}.Filter(resolved)
+	assert.NilError(t, err)
+	assert.Assert(t, first == nil)
+}
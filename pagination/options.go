@@ -0,0 +1,119 @@
+package pagination
+
+import (
+	"time"
+)
+
+// PageTokenOption configures how page tokens are encoded and parsed.
+type PageTokenOption func(*pageTokenOptions)
+
+type pageTokenOptions struct {
+	codec      TokenCodec
+	version    uint32
+	minVersion uint32
+	maxAge     time.Duration
+	now        func() time.Time
+	pageSize   *PageSizePolicy
+	checksum   []ChecksumOption
+}
+
+// WithTokenCodec sets the codec used to encode and decode page tokens.
+//
+// The default codec base64-encodes page tokens without signing or encrypting them.
+func WithTokenCodec(codec TokenCodec) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.codec = codec
+	}
+}
+
+// WithVersion sets the version of encoded page tokens.
+//
+// Increase the version together with WithMinVersion to invalidate all previously issued page tokens, for example when
+// changing how the items of a collection are stored or ordered. The default version is 0.
+func WithVersion(version uint32) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.version = version
+	}
+}
+
+// WithMinVersion sets the minimum version of parsed page tokens.
+// Parsing a page token with a lower version fails with a *PageTokenVersionError.
+func WithMinVersion(minVersion uint32) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.minVersion = minVersion
+	}
+}
+
+// WithMaxAge sets the maximum age of parsed page tokens.
+// Parsing a page token issued longer ago fails with a *PageTokenExpiredError.
+//
+// Page tokens issued before issue times were recorded are always expired when a max age is set.
+func WithMaxAge(maxAge time.Duration) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.maxAge = maxAge
+	}
+}
+
+// WithClock sets the function that returns the current time, used to record when page tokens are issued and to
+// check their age. The default is time.Now.
+func WithClock(now func() time.Time) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.now = now
+	}
+}
+
+// WithPageSizePolicy sets the page size policy of parsed page tokens.
+//
+// Parsing a page token from a request with an invalid page size fails with a validation error, and the parsed page
+// token has the effective page size of the request, see PageSizePolicy.Normalize.
+func WithPageSizePolicy(policy PageSizePolicy) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.pageSize = &policy
+	}
+}
+
+// WithChecksumOptions sets the options used to calculate the request checksum of page tokens.
+// See CalculateRequestChecksum.
+func WithChecksumOptions(checksumOptions ...ChecksumOption) PageTokenOption {
+	return func(opts *pageTokenOptions) {
+		opts.checksum = append(opts.checksum, checksumOptions...)
+	}
+}
+
+func newPageTokenOptions(opts ...PageTokenOption) pageTokenOptions {
+	options := pageTokenOptions{
+		codec: base64TokenCodec{},
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// effectivePageSize returns the effective page size of the request, or 0 without a page size policy.
+func (o *pageTokenOptions) effectivePageSize(request Request) (int32, error) {
+	if o.pageSize == nil {
+		return 0, nil
+	}
+	return o.pageSize.Normalize(request)
+}
+
+// issueTime returns the issue time to record in encoded page tokens.
+func (o *pageTokenOptions) issueTime() int64 {
+	return o.now().Unix()
+}
+
+// checkIssued checks the version and issue time of a parsed page token.
+func (o *pageTokenOptions) checkIssued(version uint32, issueTime int64) error {
+	if version < o.minVersion {
+		return &PageTokenVersionError{Version: version, MinVersion: o.minVersion}
+	}
+	if o.maxAge > 0 {
+		issued := time.Unix(issueTime, 0)
+		if issueTime == 0 || o.now().Sub(issued) > o.maxAge {
+			return &PageTokenExpiredError{IssueTime: issued, MaxAge: o.maxAge}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"go.einride.tech/aip/validation"
+)
+
+// PageSizePolicy is a policy for the page sizes of paginated requests.
+//
+// See: https://google.aip.dev/158#guidance.
+type PageSizePolicy struct {
+	// Default is the page size used when the request does not specify a page size.
+	// When zero, the max page size is used.
+	Default int32
+	// Max is the maximum page size. Larger page sizes are coerced to the max page size.
+	// When zero, page sizes are not limited.
+	Max int32
+}
+
+// Normalize returns the effective page size of the request under the policy.
+//
+// Requests with a negative page size, or a negative skip for requests that support skipping results, are invalid and
+// result in a validation error with one field violation per invalid field.
+func (p PageSizePolicy) Normalize(request Request) (int32, error) {
+	var v validation.MessageValidator
+	if request.GetPageSize() < 0 {
+		v.AddFieldViolation("page_size", "must be non-negative")
+	}
+	if s, ok := request.(skipRequest); ok && s.GetSkip() < 0 {
+		v.AddFieldViolation("skip", "must be non-negative")
+	}
+	if err := v.Err(); err != nil {
+		return 0, err
+	}
+	pageSize := request.GetPageSize()
+	if pageSize == 0 {
+		pageSize = p.Default
+		if pageSize == 0 {
+			pageSize = p.Max
+		}
+	}
+	if p.Max > 0 && pageSize > p.Max {
+		pageSize = p.Max
+	}
+	return pageSize, nil
+}
@@ -0,0 +1,119 @@
+package pagination
+
+import (
+	"testing"
+
+	freightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+func TestPageSizePolicy_Normalize(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name          string
+		policy        PageSizePolicy
+		request       Request
+		expected      int32
+		errorContains string
+	}{
+		{
+			name:     "default",
+			policy:   PageSizePolicy{Default: 100, Max: 1000},
+			request:  &library.ListBooksRequest{},
+			expected: 100,
+		},
+
+		{
+			name:     "within max",
+			policy:   PageSizePolicy{Default: 100, Max: 1000},
+			request:  &library.ListBooksRequest{PageSize: 500},
+			expected: 500,
+		},
+
+		{
+			name:     "coerced to max",
+			policy:   PageSizePolicy{Default: 100, Max: 1000},
+			request:  &library.ListBooksRequest{PageSize: 5000},
+			expected: 1000,
+		},
+
+		{
+			name:     "max as default",
+			policy:   PageSizePolicy{Max: 1000},
+			request:  &library.ListBooksRequest{},
+			expected: 1000,
+		},
+
+		{
+			name:     "unlimited",
+			policy:   PageSizePolicy{Default: 100},
+			request:  &library.ListBooksRequest{PageSize: 5000},
+			expected: 5000,
+		},
+
+		{
+			name:          "negative page size",
+			policy:        PageSizePolicy{Default: 100, Max: 1000},
+			request:       &library.ListBooksRequest{PageSize: -1},
+			errorContains: "field violation on page_size: must be non-negative",
+		},
+
+		{
+			name:     "skip",
+			policy:   PageSizePolicy{Default: 100, Max: 1000},
+			request:  &freightv1.ListSitesRequest{Skip: 10},
+			expected: 100,
+		},
+
+		{
+			name:          "negative skip",
+			policy:        PageSizePolicy{Default: 100, Max: 1000},
+			request:       &freightv1.ListSitesRequest{Skip: -10},
+			errorContains: "field violation on skip: must be non-negative",
+		},
+
+		{
+			name:          "negative page size and skip",
+			policy:        PageSizePolicy{Default: 100, Max: 1000},
+			request:       &freightv1.ListSitesRequest{PageSize: -1, Skip: -10},
+			errorContains: "field violation on multiple fields",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := tt.policy.Normalize(tt.request)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParsePageToken_WithPageSizePolicy(t *testing.T) {
+	t.Parallel()
+	policy := WithPageSizePolicy(PageSizePolicy{Default: 100, Max: 1000})
+	request1 := &library.ListBooksRequest{Parent: "shelves/1"}
+	pageToken1, err := ParsePageToken(request1, policy)
+	assert.NilError(t, err)
+	assert.Equal(t, int32(100), pageToken1.PageSize)
+	request2 := &library.ListBooksRequest{
+		Parent:    "shelves/1",
+		PageSize:  5000,
+		PageToken: pageToken1.Next(request1).String(),
+	}
+	pageToken2, err := ParsePageToken(request2, policy)
+	assert.NilError(t, err)
+	assert.Equal(t, int64(100), pageToken2.Offset)
+	assert.Equal(t, int32(1000), pageToken2.PageSize)
+	assert.Equal(t, int64(1100), pageToken2.Next(request2).Offset)
+	_, err = ParsePageToken(&library.ListBooksRequest{Parent: "shelves/1", PageSize: -1}, policy)
+	assert.ErrorContains(t, err, "page_size")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -10,6 +10,9 @@ type PageToken struct {
 	Offset int64
 	// RequestChecksum is the checksum of the request that generated the page token.
 	RequestChecksum uint32
+	// PageSize is the effective page size of the request the page token was parsed from.
+	// Only set when parsed with a page size policy, see WithPageSizePolicy.
+	PageSize int32
 }
 
 // pageTokenChecksumMask is a random bitmask applied to offset-based page token checksums.
@@ -20,13 +23,21 @@ const pageTokenChecksumMask uint32 = 0x9acb0442
 // ParsePageToken parses an offset-based page token from the provided Request.
 //
 // If the request does not have a page token, a page token with offset 0 will be returned.
-func ParsePageToken(request Request) (_ PageToken, err error) {
+//
+// The options must match the options used to encode the page token, see PageToken.Encode. Parsing fails with a
+// *PageTokenVersionError or *PageTokenExpiredError when the page token is too old, see WithMinVersion and WithMaxAge.
+func ParsePageToken(request Request, opts ...PageTokenOption) (_ PageToken, err error) {
+	options := newPageTokenOptions(opts...)
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("parse offset page token: %w", err)
 		}
 	}()
-	requestChecksum, err := CalculateRequestChecksum(request)
+	pageSize, err := options.effectivePageSize(request)
+	if err != nil {
+		return PageToken{}, err
+	}
+	requestChecksum, err := CalculateRequestChecksum(request, options.checksum...)
 	if err != nil {
 		return PageToken{}, err
 	}
@@ -39,17 +50,26 @@ func ParsePageToken(request Request) (_ PageToken, err error) {
 		return PageToken{
 			Offset:          offset,
 			RequestChecksum: requestChecksum,
+			PageSize:        pageSize,
 		}, nil
 	}
-	var pageToken PageToken
-	if err := DecodePageTokenStruct(request.GetPageToken(), &pageToken); err != nil {
+	data, err := decodePageTokenData(request.GetPageToken(), options.codec)
+	if err != nil {
+		return PageToken{}, err
+	}
+	if err := options.checkIssued(data.Version, data.IssueTime); err != nil {
 		return PageToken{}, err
 	}
-	if pageToken.RequestChecksum != requestChecksum {
+	if data.RequestChecksum != requestChecksum {
 		return PageToken{}, fmt.Errorf(
-			"checksum mismatch (got 0x%x but expected 0x%x)", pageToken.RequestChecksum, requestChecksum,
+			"checksum mismatch (got 0x%x but expected 0x%x)", data.RequestChecksum, requestChecksum,
 		)
 	}
+	pageToken := PageToken{
+		Offset:          data.Offset,
+		RequestChecksum: data.RequestChecksum,
+		PageSize:        pageSize,
+	}
 	if s, ok := request.(skipRequest); ok {
 		pageToken.Offset += int64(s.GetSkip())
 	}
@@ -57,12 +77,34 @@ func ParsePageToken(request Request) (_ PageToken, err error) {
 }
 
 // Next returns the next page token for the provided Request.
+//
+// The offset is advanced by the effective page size of the page token, when set, and otherwise by the page size of
+// the request.
 func (p PageToken) Next(request Request) PageToken {
-	p.Offset += int64(request.GetPageSize())
+	if p.PageSize > 0 {
+		p.Offset += int64(p.PageSize)
+	} else {
+		p.Offset += int64(request.GetPageSize())
+	}
 	return p
 }
 
 // String returns a string representation of the page token.
 func (p PageToken) String() string {
-	return EncodePageTokenStruct(&p)
+	return p.Encode()
+}
+
+// Encode returns a string representation of the page token, encoded with the provided options.
+//
+// The page token records the version and the current time as its issue time.
+func (p PageToken) Encode(opts ...PageTokenOption) string {
+	options := newPageTokenOptions(opts...)
+	// offset-based page tokens have no keys, and always encode
+	token, _ := encodePageTokenData(&pageTokenData{
+		Offset:          p.Offset,
+		RequestChecksum: p.RequestChecksum,
+		Version:         options.version,
+		IssueTime:       options.issueTime(),
+	}, options.codec)
+	return token
 }
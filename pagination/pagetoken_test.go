@@ -1,10 +1,14 @@
 package pagination
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	freightv1 "go.einride.tech/aip/proto/gen/einride/example/freight/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gotest.tools/v3/assert"
 )
 
@@ -147,4 +151,61 @@ func TestParseOffsetPageToken(t *testing.T) {
 		assert.ErrorContains(t, err, "checksum")
 		assert.Equal(t, PageToken{}, pageToken1)
 	})
+
+	t.Run("expiry and versioning", func(t *testing.T) {
+		t.Parallel()
+		t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := func(t time.Time) PageTokenOption {
+			return WithClock(func() time.Time { return t })
+		}
+		request1 := &library.ListBooksRequest{
+			Parent:   "shelves/1",
+			PageSize: 10,
+		}
+		pageToken1, err := ParsePageToken(request1)
+		assert.NilError(t, err)
+		request2 := &library.ListBooksRequest{
+			Parent:    "shelves/1",
+			PageSize:  10,
+			PageToken: pageToken1.Next(request1).Encode(clock(t0), WithVersion(2)),
+		}
+		pageToken2, err := ParsePageToken(request2, clock(t0.Add(time.Hour)), WithMaxAge(time.Hour), WithMinVersion(2))
+		assert.NilError(t, err)
+		assert.Equal(t, int64(10), pageToken2.Offset)
+		_, err = ParsePageToken(request2, clock(t0.Add(time.Hour+time.Second)), WithMaxAge(time.Hour))
+		var expiredErr *PageTokenExpiredError
+		assert.Assert(t, errors.As(err, &expiredErr))
+		assert.Equal(t, t0, expiredErr.IssueTime.UTC())
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = ParsePageToken(request2, WithMinVersion(3))
+		var versionErr *PageTokenVersionError
+		assert.Assert(t, errors.As(err, &versionErr))
+		assert.Equal(t, uint32(2), versionErr.Version)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("legacy page token", func(t *testing.T) {
+		t.Parallel()
+		request1 := &library.ListBooksRequest{
+			Parent:   "shelves/1",
+			PageSize: 10,
+		}
+		pageToken1, err := ParsePageToken(request1)
+		assert.NilError(t, err)
+		// Page tokens encoded before versions and issue times were recorded.
+		request2 := &library.ListBooksRequest{
+			Parent:    "shelves/1",
+			PageSize:  10,
+			PageToken: EncodePageTokenStruct(pageToken1.Next(request1)),
+		}
+		pageToken2, err := ParsePageToken(request2)
+		assert.NilError(t, err)
+		assert.Equal(t, int64(10), pageToken2.Offset)
+		_, err = ParsePageToken(request2, WithMaxAge(time.Hour))
+		var expiredErr *PageTokenExpiredError
+		assert.Assert(t, errors.As(err, &expiredErr))
+		_, err = ParsePageToken(request2, WithMinVersion(1))
+		var versionErr *PageTokenVersionError
+		assert.Assert(t, errors.As(err, &versionErr))
+	})
 }
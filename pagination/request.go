@@ -3,8 +3,11 @@ package pagination
 import (
 	"fmt"
 	"hash/crc32"
+	"slices"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // Request is an interface for paginated request messages.
@@ -25,19 +28,84 @@ type skipRequest interface {
 	GetSkip() int32
 }
 
+// ChecksumOption configures CalculateRequestChecksum.
+type ChecksumOption func(*checksumOptions)
+
+type checksumOptions struct {
+	excludedFields []string
+	includedFields []string
+}
+
+// WithChecksumExcludedFields excludes additional fields from the request checksum, such as fields that may change
+// between calls. Paths of nested fields are separated by dots, such as trace.id.
+func WithChecksumExcludedFields(paths ...string) ChecksumOption {
+	return func(opts *checksumOptions) {
+		opts.excludedFields = append(opts.excludedFields, paths...)
+	}
+}
+
+// WithChecksumIncludedFields includes fields in the request checksum that are otherwise excluded, such as page_size
+// or skip, so that they must be the same across calls.
+func WithChecksumIncludedFields(paths ...string) ChecksumOption {
+	return func(opts *checksumOptions) {
+		opts.includedFields = append(opts.includedFields, paths...)
+	}
+}
+
 // CalculateRequestChecksum calculates a checksum for all fields of the request that must be the same across calls.
-func CalculateRequestChecksum(request Request) (uint32, error) {
-	// Clone the original request, clear fields that may vary across calls, then checksum the resulting message.
+//
+// The page_token, page_size and skip fields are excluded from the checksum by default.
+// Fields are marshaled deterministically, so that requests with map fields have stable checksums.
+func CalculateRequestChecksum(request Request, opts ...ChecksumOption) (uint32, error) {
+	data, err := marshalRequestChecksumFields(request, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("calculate request checksum: %w", err)
+	}
+	return crc32.ChecksumIEEE(data), nil
+}
+
+// marshalRequestChecksumFields deterministically marshals the fields of the request that are included in the request
+// checksum. See CalculateRequestChecksum.
+func marshalRequestChecksumFields(request Request, opts ...ChecksumOption) ([]byte, error) {
+	var options checksumOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	// Clone the original request, clear fields that may vary across calls, then marshal the resulting message.
 	clonedRequest := proto.Clone(request)
 	r := clonedRequest.ProtoReflect()
-	r.Clear(r.Descriptor().Fields().ByName("page_token"))
-	r.Clear(r.Descriptor().Fields().ByName("page_size"))
+	excludedFields := []string{"page_token", "page_size"}
 	if _, ok := request.(skipRequest); ok {
-		r.Clear(r.Descriptor().Fields().ByName("skip"))
+		excludedFields = append(excludedFields, "skip")
 	}
-	data, err := proto.Marshal(clonedRequest)
-	if err != nil {
-		return 0, fmt.Errorf("calculate request checksum: %w", err)
+	excludedFields = append(excludedFields, options.excludedFields...)
+	for _, path := range excludedFields {
+		if slices.Contains(options.includedFields, path) {
+			continue
+		}
+		if err := clearFieldPath(r, path); err != nil {
+			return nil, err
+		}
 	}
-	return crc32.ChecksumIEEE(data), nil
+	return proto.MarshalOptions{Deterministic: true}.Marshal(clonedRequest)
+}
+
+// clearFieldPath clears the field at the dot-separated path in the message, if set.
+func clearFieldPath(m protoreflect.Message, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || (i < len(names)-1 && (fd.Message() == nil || fd.IsList() || fd.IsMap())) {
+			return fmt.Errorf("invalid field path: %s", path)
+		}
+		if i == len(names)-1 {
+			m.Clear(fd)
+			return nil
+		}
+		if !m.Has(fd) {
+			return nil
+		}
+		m = m.Mutable(fd).Message()
+	}
+	return nil
 }
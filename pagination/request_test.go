@@ -14,6 +14,7 @@ func TestCalculateRequestChecksum(t *testing.T) {
 		name     string
 		request1 Request
 		request2 Request
+		opts     []ChecksumOption
 		equal    bool
 	}{
 		{
@@ -86,12 +87,51 @@ func TestCalculateRequestChecksum(t *testing.T) {
 			},
 			equal: true,
 		},
+		{
+			name: "excluded field",
+			request1: &library.ListBooksRequest{
+				Parent:   "shelves/1",
+				PageSize: 100,
+			},
+			request2: &library.ListBooksRequest{
+				Parent:   "shelves/2",
+				PageSize: 100,
+			},
+			opts:  []ChecksumOption{WithChecksumExcludedFields("parent")},
+			equal: true,
+		},
+		{
+			name: "included page size",
+			request1: &library.ListBooksRequest{
+				Parent:   "shelves/1",
+				PageSize: 100,
+			},
+			request2: &library.ListBooksRequest{
+				Parent:   "shelves/1",
+				PageSize: 200,
+			},
+			opts:  []ChecksumOption{WithChecksumIncludedFields("page_size")},
+			equal: false,
+		},
+		{
+			name: "included skip",
+			request1: &freightv1.ListSitesRequest{
+				Parent: "shippers/1",
+				Skip:   0,
+			},
+			request2: &freightv1.ListSitesRequest{
+				Parent: "shippers/1",
+				Skip:   30,
+			},
+			opts:  []ChecksumOption{WithChecksumIncludedFields("skip")},
+			equal: false,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			checksum1, err := CalculateRequestChecksum(tt.request1)
+			checksum1, err := CalculateRequestChecksum(tt.request1, tt.opts...)
 			assert.NilError(t, err)
-			checksum2, err := CalculateRequestChecksum(tt.request2)
+			checksum2, err := CalculateRequestChecksum(tt.request2, tt.opts...)
 			assert.NilError(t, err)
 			if tt.equal {
 				assert.Assert(t, checksum1 == checksum2)
@@ -101,3 +141,35 @@ func TestCalculateRequestChecksum(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateRequestChecksum_InvalidFieldPath(t *testing.T) {
+	t.Parallel()
+	for _, path := range []string{"", "foo", "parent.foo"} {
+		_, err := CalculateRequestChecksum(
+			&library.ListBooksRequest{Parent: "shelves/1"},
+			WithChecksumExcludedFields(path),
+		)
+		assert.ErrorContains(t, err, "invalid field path: "+path)
+	}
+}
+
+func TestParsePageToken_WithChecksumOptions(t *testing.T) {
+	t.Parallel()
+	checksumOptions := WithChecksumOptions(WithChecksumExcludedFields("parent"))
+	request1 := &library.ListBooksRequest{
+		Parent:   "shelves/1",
+		PageSize: 10,
+	}
+	pageToken1, err := ParsePageToken(request1, checksumOptions)
+	assert.NilError(t, err)
+	request2 := &library.ListBooksRequest{
+		Parent:    "shelves/2",
+		PageSize:  10,
+		PageToken: pageToken1.Next(request1).String(),
+	}
+	pageToken2, err := ParsePageToken(request2, checksumOptions)
+	assert.NilError(t, err)
+	assert.Equal(t, int64(10), pageToken2.Offset)
+	_, err = ParsePageToken(request2)
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
@@ -10,6 +10,9 @@ import (
 )
 
 // EncodePageTokenStruct encodes an arbitrary struct as a page token.
+//
+// The struct is encoded with encoding/gob, which is only readable by Go programs. PageToken and KeysetPageToken use a
+// compact protobuf-based format instead, see the package documentation.
 func EncodePageTokenStruct(v interface{}) string {
 	var b strings.Builder
 	base64Encoder := base64.NewEncoder(base64.URLEncoding, &b)
@@ -20,6 +23,8 @@ func EncodePageTokenStruct(v interface{}) string {
 }
 
 // DecodePageTokenStruct decodes an encoded page token into an arbitrary struct.
+//
+// Page tokens encoded by PageToken before the protobuf-based format can still be decoded into a PageToken.
 func DecodePageTokenStruct(s string, v interface{}) error {
 	dec := gob.NewDecoder(base64.NewDecoder(base64.URLEncoding, strings.NewReader(s)))
 	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
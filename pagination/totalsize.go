@@ -0,0 +1,215 @@
+package pagination
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SetTotalSize sets the total_size field of the response, which can be an int32 or int64 field.
+//
+// See: https://google.aip.dev/158#total-count.
+func SetTotalSize(response proto.Message, totalSize int64) error {
+	m := response.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("total_size")
+	if fd == nil || fd.Cardinality() == protoreflect.Repeated {
+		return fmt.Errorf("set total size: no field total_size in %s", m.Descriptor().FullName())
+	}
+	switch fd.Kind() {
+	case protoreflect.Int32Kind:
+		if totalSize > math.MaxInt32 || totalSize < math.MinInt32 {
+			return fmt.Errorf("set total size: %d out of range for int32 field total_size", totalSize)
+		}
+		m.Set(fd, protoreflect.ValueOfInt32(int32(totalSize)))
+	case protoreflect.Int64Kind:
+		m.Set(fd, protoreflect.ValueOfInt64(totalSize))
+	default:
+		return fmt.Errorf("set total size: unsupported kind %s of field total_size", fd.Kind())
+	}
+	return nil
+}
+
+// TotalSizeOption configures SetTotalSizeFromCount.
+type TotalSizeOption func(*totalSizeOptions)
+
+type totalSizeOptions struct {
+	cache      *TotalSizeCache
+	cacheScope string
+	checksum   []ChecksumOption
+}
+
+// WithTotalSizeCache sets a cache for total sizes, so that consecutive pages of the same request only count once.
+//
+// Total sizes are only shared between requests with the same scope, which must identify everything that the count
+// depends on besides the request, such as the principal, tenant and authorization scope of the caller.
+func WithTotalSizeCache(cache *TotalSizeCache, scope string) TotalSizeOption {
+	return func(opts *totalSizeOptions) {
+		opts.cache = cache
+		opts.cacheScope = scope
+	}
+}
+
+// WithTotalSizeChecksumOptions sets the options used to identify the request in the total size cache, which should be
+// the same as the checksum options of the page tokens. See WithChecksumOptions and CalculateRequestChecksum.
+func WithTotalSizeChecksumOptions(checksumOptions ...ChecksumOption) TotalSizeOption {
+	return func(opts *totalSizeOptions) {
+		opts.checksum = append(opts.checksum, checksumOptions...)
+	}
+}
+
+// SetTotalSizeFromCount sets the total_size field of the response to the result of count.
+//
+// With a cache, the total size of the request is only counted when not already cached.
+// See SetTotalSize and WithTotalSizeCache.
+func SetTotalSizeFromCount(
+	ctx context.Context,
+	request Request,
+	response proto.Message,
+	count func(context.Context) (int64, error),
+	opts ...TotalSizeOption,
+) error {
+	var options totalSizeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.cache != nil {
+		uncached := count
+		count = func(ctx context.Context) (int64, error) {
+			return options.cache.Count(ctx, options.cacheScope, request, uncached, options.checksum...)
+		}
+	}
+	totalSize, err := count(ctx)
+	if err != nil {
+		return err
+	}
+	return SetTotalSize(response, totalSize)
+}
+
+// TotalSizeCache caches total sizes of paginated requests, keyed by a caller-provided scope, the type of the request
+// and a SHA-256 hash of the fields of the request that are included in the request checksum.
+// See CalculateRequestChecksum.
+//
+// Cached total sizes are approximate, since items may be created or deleted before the cached entry expires.
+// Expired entries are evicted when new entries are cached, so the cache holds at most the entries cached within the
+// last ttl, and at most maxEntries entries when limited.
+//
+// A TotalSizeCache is safe for concurrent use.
+type TotalSizeCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	mu         sync.Mutex
+	entries    map[totalSizeCacheKey]*list.Element
+	// expiry orders the entries by expire time, from the entry closest to expiry.
+	expiry *list.List
+}
+
+type totalSizeCacheKey [sha256.Size]byte
+
+type totalSizeCacheEntry struct {
+	key        totalSizeCacheKey
+	totalSize  int64
+	expireTime time.Time
+}
+
+// NewTotalSizeCache creates a new cache of total sizes, where entries expire after ttl.
+// At most maxEntries entries are cached, or unlimited when maxEntries is zero.
+func NewTotalSizeCache(ttl time.Duration, maxEntries int) *TotalSizeCache {
+	return &TotalSizeCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    map[totalSizeCacheKey]*list.Element{},
+		expiry:     list.New(),
+	}
+}
+
+// Count returns the cached total size of the request in the scope, or caches and returns the result of count.
+//
+// The scope must identify everything that the count depends on besides the request, such as the principal, tenant and
+// authorization scope of the caller, so that total sizes are not shared between callers that may see different items.
+// The checksum options should be the same as the checksum options of the page tokens, see WithChecksumOptions.
+func (c *TotalSizeCache) Count(
+	ctx context.Context,
+	scope string,
+	request Request,
+	count func(context.Context) (int64, error),
+	opts ...ChecksumOption,
+) (int64, error) {
+	key, err := newTotalSizeCacheKey(scope, request, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if totalSize, ok := c.get(key); ok {
+		return totalSize, nil
+	}
+	totalSize, err := count(ctx)
+	if err != nil {
+		return 0, err
+	}
+	c.set(key, totalSize)
+	return totalSize, nil
+}
+
+func newTotalSizeCacheKey(scope string, request Request, opts ...ChecksumOption) (totalSizeCacheKey, error) {
+	data, err := marshalRequestChecksumFields(request, opts...)
+	if err != nil {
+		return totalSizeCacheKey{}, fmt.Errorf("total size cache key: %w", err)
+	}
+	// Length-prefix each part, so that different parts never produce the same hashed bytes.
+	var b []byte
+	b = protowire.AppendString(b, scope)
+	b = protowire.AppendString(b, string(request.ProtoReflect().Descriptor().FullName()))
+	b = protowire.AppendBytes(b, data)
+	return sha256.Sum256(b), nil
+}
+
+func (c *TotalSizeCache) get(key totalSizeCacheKey) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	entry := element.Value.(*totalSizeCacheEntry)
+	if !c.now().Before(entry.expireTime) {
+		c.removeLocked(element)
+		return 0, false
+	}
+	return entry.totalSize, true
+}
+
+func (c *TotalSizeCache) set(key totalSizeCacheKey, totalSize int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.removeLocked(element)
+	}
+	now := c.now()
+	// Entries all have the same ttl, so expired entries and the entries closest to expiry are first.
+	for element := c.expiry.Front(); element != nil; element = c.expiry.Front() {
+		expired := !now.Before(element.Value.(*totalSizeCacheEntry).expireTime)
+		if !expired && (c.maxEntries <= 0 || c.expiry.Len() < c.maxEntries) {
+			break
+		}
+		c.removeLocked(element)
+	}
+	c.entries[key] = c.expiry.PushBack(&totalSizeCacheEntry{
+		key:        key,
+		totalSize:  totalSize,
+		expireTime: now.Add(c.ttl),
+	})
+}
+
+func (c *TotalSizeCache) removeLocked(element *list.Element) {
+	c.expiry.Remove(element)
+	delete(c.entries, element.Value.(*totalSizeCacheEntry).key)
+}
@@ -0,0 +1,144 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	syntaxv1 "go.einride.tech/aip/proto/gen/einride/example/syntax/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestSetTotalSize(t *testing.T) {
+	t.Parallel()
+	t.Run("int32", func(t *testing.T) {
+		t.Parallel()
+		response := &syntaxv1.Int32TotalSizeResponse{}
+		assert.NilError(t, SetTotalSize(response, 42))
+		assert.Equal(t, int32(42), response.GetTotalSize())
+		assert.ErrorContains(t, SetTotalSize(response, math.MaxInt32+1), "out of range")
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		t.Parallel()
+		response := &syntaxv1.Int64TotalSizeResponse{}
+		assert.NilError(t, SetTotalSize(response, math.MaxInt32+1))
+		assert.Equal(t, int64(math.MaxInt32+1), response.GetTotalSize())
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorContains(t, SetTotalSize(&syntaxv1.StringTotalSizeResponse{}, 42), "unsupported kind string")
+	})
+
+	t.Run("no field", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorContains(
+			t,
+			SetTotalSize(&library.ListShelvesResponse{}, 42),
+			"no field total_size in google.example.library.v1.ListShelvesResponse",
+		)
+	})
+
+	t.Run("from count", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		cache := NewTotalSizeCache(time.Minute, 0)
+		var counts int
+		count := func(context.Context) (int64, error) {
+			counts++
+			return 42, nil
+		}
+		request1 := &library.ListBooksRequest{Parent: "shelves/1", PageSize: 10}
+		response1 := &syntaxv1.Int32TotalSizeResponse{}
+		assert.NilError(t, SetTotalSizeFromCount(ctx, request1, response1, count, WithTotalSizeCache(cache, "users/1")))
+		assert.Equal(t, int32(42), response1.GetTotalSize())
+		request2 := &library.ListBooksRequest{Parent: "shelves/1", PageSize: 20, PageToken: "next"}
+		response2 := &syntaxv1.Int32TotalSizeResponse{}
+		assert.NilError(t, SetTotalSizeFromCount(ctx, request2, response2, count, WithTotalSizeCache(cache, "users/1")))
+		assert.Equal(t, int32(42), response2.GetTotalSize())
+		assert.Equal(t, 1, counts)
+		assert.NilError(t, SetTotalSizeFromCount(ctx, request2, response2, count))
+		assert.Equal(t, 2, counts)
+		// Total sizes are not shared between scopes.
+		assert.NilError(t, SetTotalSizeFromCount(ctx, request2, response2, count, WithTotalSizeCache(cache, "users/2")))
+		assert.Equal(t, 3, counts)
+		// Fields included by the checksum options identify the request.
+		assert.NilError(t, SetTotalSizeFromCount(
+			ctx,
+			request2,
+			response2,
+			count,
+			WithTotalSizeCache(cache, "users/1"),
+			WithTotalSizeChecksumOptions(WithChecksumIncludedFields("page_size")),
+		))
+		assert.Equal(t, 4, counts)
+		assert.Error(
+			t,
+			SetTotalSizeFromCount(ctx, request2, response2, func(context.Context) (int64, error) {
+				return 0, errors.New("boom")
+			}),
+			"boom",
+		)
+	})
+}
+
+func TestTotalSizeCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewTotalSizeCache(time.Minute, 2)
+	cache.now = func() time.Time { return now }
+	var counts int
+	count := func(context.Context) (int64, error) {
+		counts++
+		return int64(counts), nil
+	}
+	shelf1 := &library.ListBooksRequest{Parent: "shelves/1"}
+	shelf2 := &library.ListBooksRequest{Parent: "shelves/2"}
+	shelf3 := &library.ListBooksRequest{Parent: "shelves/3"}
+	for _, tt := range []struct {
+		request  Request
+		advance  time.Duration
+		expected int64
+	}{
+		{request: shelf1, expected: 1},
+		{request: shelf1, expected: 1},
+		{request: &library.ListShelvesRequest{}, advance: time.Second, expected: 2}, // different request type
+		{request: shelf2, advance: time.Second, expected: 3},                        // evicts shelf1
+		{request: &library.ListShelvesRequest{}, expected: 2},
+		{request: shelf1, expected: 4},                       // evicts ListShelvesRequest
+		{request: shelf2, advance: time.Minute, expected: 5}, // expired
+		{request: shelf3, expected: 6},
+		{request: shelf2, expected: 5},
+	} {
+		now = now.Add(tt.advance)
+		actual, err := cache.Count(ctx, "", tt.request, count)
+		assert.NilError(t, err)
+		assert.Equal(t, tt.expected, actual)
+	}
+}
+
+func TestTotalSizeCache_Expiry(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewTotalSizeCache(time.Minute, 0)
+	cache.now = func() time.Time { return now }
+	count := func(context.Context) (int64, error) {
+		return 42, nil
+	}
+	for i := 0; i < 10; i++ {
+		request := &library.ListBooksRequest{Parent: fmt.Sprintf("shelves/%d", i)}
+		_, err := cache.Count(ctx, "", request, count)
+		assert.NilError(t, err)
+		now = now.Add(20 * time.Second)
+	}
+	// Only the entries cached within the last minute remain.
+	assert.Equal(t, 3, len(cache.entries))
+	assert.Equal(t, 3, cache.expiry.Len())
+}
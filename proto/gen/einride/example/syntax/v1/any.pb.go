@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: einride/example/syntax/v1/any.proto
+
+package syntaxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AnyMessage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Details         *anypb.Any             `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	RepeatedDetails []*anypb.Any           `protobuf:"bytes,3,rep,name=repeated_details,json=repeatedDetails,proto3" json:"repeated_details,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AnyMessage) Reset() {
+	*x = AnyMessage{}
+	mi := &file_einride_example_syntax_v1_any_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnyMessage) ProtoMessage() {}
+
+func (x *AnyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_any_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnyMessage.ProtoReflect.Descriptor instead.
+func (*AnyMessage) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_any_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AnyMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AnyMessage) GetDetails() *anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *AnyMessage) GetRepeatedDetails() []*anypb.Any {
+	if x != nil {
+		return x.RepeatedDetails
+	}
+	return nil
+}
+
+var File_einride_example_syntax_v1_any_proto protoreflect.FileDescriptor
+
+const file_einride_example_syntax_v1_any_proto_rawDesc = "" +
+	"\n" +
+	"#einride/example/syntax/v1/any.proto\x12\x19einride.example.syntax.v1\x1a\x19google/protobuf/any.proto\"\x91\x01\n" +
+	"\n" +
+	"AnyMessage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12.\n" +
+	"\adetails\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\adetails\x12?\n" +
+	"\x10repeated_details\x18\x03 \x03(\v2\x14.google.protobuf.AnyR\x0frepeatedDetailsB\xf2\x01\n" +
+	"\x1dcom.einride.example.syntax.v1B\bAnyProtoP\x01Z@go.einride.tech/aip/proto/gen/einride/example/syntax/v1;syntaxv1\xa2\x02\x03EES\xaa\x02\x19Einride.Example.Syntax.V1\xca\x02\x19Einride\\Example\\Syntax\\V1\xe2\x02%Einride\\Example\\Syntax\\V1\\GPBMetadata\xea\x02\x1cEinride::Example::Syntax::V1b\x06proto3"
+
+var (
+	file_einride_example_syntax_v1_any_proto_rawDescOnce sync.Once
+	file_einride_example_syntax_v1_any_proto_rawDescData []byte
+)
+
+func file_einride_example_syntax_v1_any_proto_rawDescGZIP() []byte {
+	file_einride_example_syntax_v1_any_proto_rawDescOnce.Do(func() {
+		file_einride_example_syntax_v1_any_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_any_proto_rawDesc), len(file_einride_example_syntax_v1_any_proto_rawDesc)))
+	})
+	return file_einride_example_syntax_v1_any_proto_rawDescData
+}
+
+var file_einride_example_syntax_v1_any_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_example_syntax_v1_any_proto_goTypes = []any{
+	(*AnyMessage)(nil), // 0: einride.example.syntax.v1.AnyMessage
+	(*anypb.Any)(nil),  // 1: google.protobuf.Any
+}
+var file_einride_example_syntax_v1_any_proto_depIdxs = []int32{
+	1, // 0: einride.example.syntax.v1.AnyMessage.details:type_name -> google.protobuf.Any
+	1, // 1: einride.example.syntax.v1.AnyMessage.repeated_details:type_name -> google.protobuf.Any
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_einride_example_syntax_v1_any_proto_init() }
+func file_einride_example_syntax_v1_any_proto_init() {
+	if File_einride_example_syntax_v1_any_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_any_proto_rawDesc), len(file_einride_example_syntax_v1_any_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_example_syntax_v1_any_proto_goTypes,
+		DependencyIndexes: file_einride_example_syntax_v1_any_proto_depIdxs,
+		MessageInfos:      file_einride_example_syntax_v1_any_proto_msgTypes,
+	}.Build()
+	File_einride_example_syntax_v1_any_proto = out.File
+	file_einride_example_syntax_v1_any_proto_goTypes = nil
+	file_einride_example_syntax_v1_any_proto_depIdxs = nil
+}
@@ -284,6 +284,110 @@ func (x *SmallFieldBehaviorMessage) GetOptionalField() string {
 	return ""
 }
 
+type RequiredFieldBehaviorMessage struct {
+	state         protoimpl.MessageState                   `protogen:"open.v1"`
+	RequiredField string                                   `protobuf:"bytes,1,opt,name=required_field,json=requiredField,proto3" json:"required_field,omitempty"`
+	MapMessage    map[string]*RequiredFieldBehaviorMessage `protobuf:"bytes,2,rep,name=map_message,json=mapMessage,proto3" json:"map_message,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequiredFieldBehaviorMessage) Reset() {
+	*x = RequiredFieldBehaviorMessage{}
+	mi := &file_einride_example_syntax_v1_fieldbehaviors_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequiredFieldBehaviorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequiredFieldBehaviorMessage) ProtoMessage() {}
+
+func (x *RequiredFieldBehaviorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_fieldbehaviors_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequiredFieldBehaviorMessage.ProtoReflect.Descriptor instead.
+func (*RequiredFieldBehaviorMessage) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_fieldbehaviors_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequiredFieldBehaviorMessage) GetRequiredField() string {
+	if x != nil {
+		return x.RequiredField
+	}
+	return ""
+}
+
+func (x *RequiredFieldBehaviorMessage) GetMapMessage() map[string]*RequiredFieldBehaviorMessage {
+	if x != nil {
+		return x.MapMessage
+	}
+	return nil
+}
+
+type IdentifierFieldBehaviorMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentifierFieldBehaviorMessage) Reset() {
+	*x = IdentifierFieldBehaviorMessage{}
+	mi := &file_einride_example_syntax_v1_fieldbehaviors_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentifierFieldBehaviorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentifierFieldBehaviorMessage) ProtoMessage() {}
+
+func (x *IdentifierFieldBehaviorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_fieldbehaviors_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentifierFieldBehaviorMessage.ProtoReflect.Descriptor instead.
+func (*IdentifierFieldBehaviorMessage) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_fieldbehaviors_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IdentifierFieldBehaviorMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IdentifierFieldBehaviorMessage) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
 var File_einride_example_syntax_v1_fieldbehaviors_proto protoreflect.FileDescriptor
 
 const file_einride_example_syntax_v1_fieldbehaviors_proto_rawDesc = "" +
@@ -327,7 +431,17 @@ const file_einride_example_syntax_v1_fieldbehaviors_proto_rawDesc = "" +
 	"\x19SmallFieldBehaviorMessage\x12\x14\n" +
 	"\x05field\x18\x01 \x01(\tR\x05field\x120\n" +
 	"\x11output_only_field\x18\x02 \x01(\tB\x04\xe2A\x01\x03R\x0foutputOnlyField\x12+\n" +
-	"\x0eoptional_field\x18\x03 \x01(\tB\x04\xe2A\x01\x01R\roptionalFieldB\xfd\x01\n" +
+	"\x0eoptional_field\x18\x03 \x01(\tB\x04\xe2A\x01\x01R\roptionalField\"\xad\x02\n" +
+	"\x1cRequiredFieldBehaviorMessage\x12+\n" +
+	"\x0erequired_field\x18\x01 \x01(\tB\x04\xe2A\x01\x02R\rrequiredField\x12h\n" +
+	"\vmap_message\x18\x02 \x03(\v2G.einride.example.syntax.v1.RequiredFieldBehaviorMessage.MapMessageEntryR\n" +
+	"mapMessage\x1av\n" +
+	"\x0fMapMessageEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12M\n" +
+	"\x05value\x18\x02 \x01(\v27.einride.example.syntax.v1.RequiredFieldBehaviorMessageR\x05value:\x028\x01\"]\n" +
+	"\x1eIdentifierFieldBehaviorMessage\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\xe2A\x01\bR\x04name\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayNameB\xfd\x01\n" +
 	"\x1dcom.einride.example.syntax.v1B\x13FieldbehaviorsProtoP\x01Z@go.einride.tech/aip/proto/gen/einride/example/syntax/v1;syntaxv1\xa2\x02\x03EES\xaa\x02\x19Einride.Example.Syntax.V1\xca\x02\x19Einride\\Example\\Syntax\\V1\xe2\x02%Einride\\Example\\Syntax\\V1\\GPBMetadata\xea\x02\x1cEinride::Example::Syntax::V1b\x06proto3"
 
 var (
@@ -342,14 +456,17 @@ func file_einride_example_syntax_v1_fieldbehaviors_proto_rawDescGZIP() []byte {
 	return file_einride_example_syntax_v1_fieldbehaviors_proto_rawDescData
 }
 
-var file_einride_example_syntax_v1_fieldbehaviors_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_einride_example_syntax_v1_fieldbehaviors_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_einride_example_syntax_v1_fieldbehaviors_proto_goTypes = []any{
-	(*FieldBehaviorMessage)(nil),      // 0: einride.example.syntax.v1.FieldBehaviorMessage
-	(*SmallFieldBehaviorMessage)(nil), // 1: einride.example.syntax.v1.SmallFieldBehaviorMessage
-	nil,                               // 2: einride.example.syntax.v1.FieldBehaviorMessage.MapMessageEntry
-	nil,                               // 3: einride.example.syntax.v1.FieldBehaviorMessage.MapOutputOnlyMessageEntry
-	nil,                               // 4: einride.example.syntax.v1.FieldBehaviorMessage.MapOptionalMessageEntry
-	nil,                               // 5: einride.example.syntax.v1.FieldBehaviorMessage.StringMapEntry
+	(*FieldBehaviorMessage)(nil),           // 0: einride.example.syntax.v1.FieldBehaviorMessage
+	(*SmallFieldBehaviorMessage)(nil),      // 1: einride.example.syntax.v1.SmallFieldBehaviorMessage
+	(*RequiredFieldBehaviorMessage)(nil),   // 2: einride.example.syntax.v1.RequiredFieldBehaviorMessage
+	(*IdentifierFieldBehaviorMessage)(nil), // 3: einride.example.syntax.v1.IdentifierFieldBehaviorMessage
+	nil,                                    // 4: einride.example.syntax.v1.FieldBehaviorMessage.MapMessageEntry
+	nil,                                    // 5: einride.example.syntax.v1.FieldBehaviorMessage.MapOutputOnlyMessageEntry
+	nil,                                    // 6: einride.example.syntax.v1.FieldBehaviorMessage.MapOptionalMessageEntry
+	nil,                                    // 7: einride.example.syntax.v1.FieldBehaviorMessage.StringMapEntry
+	nil,                                    // 8: einride.example.syntax.v1.RequiredFieldBehaviorMessage.MapMessageEntry
 }
 var file_einride_example_syntax_v1_fieldbehaviors_proto_depIdxs = []int32{
 	0,  // 0: einride.example.syntax.v1.FieldBehaviorMessage.message_without_field_behavior:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
@@ -358,20 +475,22 @@ var file_einride_example_syntax_v1_fieldbehaviors_proto_depIdxs = []int32{
 	0,  // 3: einride.example.syntax.v1.FieldBehaviorMessage.repeated_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
 	0,  // 4: einride.example.syntax.v1.FieldBehaviorMessage.repeated_output_only_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
 	0,  // 5: einride.example.syntax.v1.FieldBehaviorMessage.repeated_optional_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
-	2,  // 6: einride.example.syntax.v1.FieldBehaviorMessage.map_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.MapMessageEntry
-	3,  // 7: einride.example.syntax.v1.FieldBehaviorMessage.map_output_only_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.MapOutputOnlyMessageEntry
-	4,  // 8: einride.example.syntax.v1.FieldBehaviorMessage.map_optional_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.MapOptionalMessageEntry
-	5,  // 9: einride.example.syntax.v1.FieldBehaviorMessage.string_map:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.StringMapEntry
+	4,  // 6: einride.example.syntax.v1.FieldBehaviorMessage.map_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.MapMessageEntry
+	5,  // 7: einride.example.syntax.v1.FieldBehaviorMessage.map_output_only_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.MapOutputOnlyMessageEntry
+	6,  // 8: einride.example.syntax.v1.FieldBehaviorMessage.map_optional_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.MapOptionalMessageEntry
+	7,  // 9: einride.example.syntax.v1.FieldBehaviorMessage.string_map:type_name -> einride.example.syntax.v1.FieldBehaviorMessage.StringMapEntry
 	0,  // 10: einride.example.syntax.v1.FieldBehaviorMessage.field_behavior_message:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
 	1,  // 11: einride.example.syntax.v1.FieldBehaviorMessage.small_field_behavior_message:type_name -> einride.example.syntax.v1.SmallFieldBehaviorMessage
-	0,  // 12: einride.example.syntax.v1.FieldBehaviorMessage.MapMessageEntry.value:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
-	0,  // 13: einride.example.syntax.v1.FieldBehaviorMessage.MapOutputOnlyMessageEntry.value:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
-	0,  // 14: einride.example.syntax.v1.FieldBehaviorMessage.MapOptionalMessageEntry.value:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
-	15, // [15:15] is the sub-list for method output_type
-	15, // [15:15] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	8,  // 12: einride.example.syntax.v1.RequiredFieldBehaviorMessage.map_message:type_name -> einride.example.syntax.v1.RequiredFieldBehaviorMessage.MapMessageEntry
+	0,  // 13: einride.example.syntax.v1.FieldBehaviorMessage.MapMessageEntry.value:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
+	0,  // 14: einride.example.syntax.v1.FieldBehaviorMessage.MapOutputOnlyMessageEntry.value:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
+	0,  // 15: einride.example.syntax.v1.FieldBehaviorMessage.MapOptionalMessageEntry.value:type_name -> einride.example.syntax.v1.FieldBehaviorMessage
+	2,  // 16: einride.example.syntax.v1.RequiredFieldBehaviorMessage.MapMessageEntry.value:type_name -> einride.example.syntax.v1.RequiredFieldBehaviorMessage
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_einride_example_syntax_v1_fieldbehaviors_proto_init() }
@@ -389,7 +508,7 @@ func file_einride_example_syntax_v1_fieldbehaviors_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_fieldbehaviors_proto_rawDesc), len(file_einride_example_syntax_v1_fieldbehaviors_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
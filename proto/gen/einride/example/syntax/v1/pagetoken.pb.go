@@ -0,0 +1,379 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: einride/example/syntax/v1/pagetoken.proto
+
+package syntaxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The wire format of the page tokens in go.einride.tech/aip/pagination.
+//
+// A page token is the binary serialization of this message, encoded to a string by a token codec.
+type PageToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Offset of an offset-based page token.
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Checksum of the request, as computed by pagination.CalculateRequestChecksum and masked with a page token type
+	// specific bitmask.
+	RequestChecksum uint32 `protobuf:"fixed32,2,opt,name=request_checksum,json=requestChecksum,proto3" json:"request_checksum,omitempty"`
+	// Version of the page token, see pagination.WithVersion.
+	Version uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Issue time of the page token, in seconds since the Unix epoch.
+	IssueTime int64 `protobuf:"varint,4,opt,name=issue_time,json=issueTime,proto3" json:"issue_time,omitempty"`
+	// Ordering key values of a keyset-based page token.
+	Keys          []*PageToken_Key `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageToken) Reset() {
+	*x = PageToken{}
+	mi := &file_einride_example_syntax_v1_pagetoken_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageToken) ProtoMessage() {}
+
+func (x *PageToken) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_pagetoken_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageToken.ProtoReflect.Descriptor instead.
+func (*PageToken) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_pagetoken_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageToken) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PageToken) GetRequestChecksum() uint32 {
+	if x != nil {
+		return x.RequestChecksum
+	}
+	return 0
+}
+
+func (x *PageToken) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PageToken) GetIssueTime() int64 {
+	if x != nil {
+		return x.IssueTime
+	}
+	return 0
+}
+
+func (x *PageToken) GetKeys() []*PageToken_Key {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// An ordering key value.
+type PageToken_Key struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*PageToken_Key_BoolValue
+	//	*PageToken_Key_IntValue
+	//	*PageToken_Key_UintValue
+	//	*PageToken_Key_DoubleValue
+	//	*PageToken_Key_StringValue
+	//	*PageToken_Key_BytesValue
+	//	*PageToken_Key_TimestampValue
+	//	*PageToken_Key_DurationValue
+	Value         isPageToken_Key_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageToken_Key) Reset() {
+	*x = PageToken_Key{}
+	mi := &file_einride_example_syntax_v1_pagetoken_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageToken_Key) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageToken_Key) ProtoMessage() {}
+
+func (x *PageToken_Key) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_pagetoken_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageToken_Key.ProtoReflect.Descriptor instead.
+func (*PageToken_Key) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_pagetoken_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *PageToken_Key) GetValue() isPageToken_Key_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *PageToken_Key) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *PageToken_Key) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *PageToken_Key) GetUintValue() uint64 {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_UintValue); ok {
+			return x.UintValue
+		}
+	}
+	return 0
+}
+
+func (x *PageToken_Key) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *PageToken_Key) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *PageToken_Key) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *PageToken_Key) GetTimestampValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+func (x *PageToken_Key) GetDurationValue() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Value.(*PageToken_Key_DurationValue); ok {
+			return x.DurationValue
+		}
+	}
+	return nil
+}
+
+type isPageToken_Key_Value interface {
+	isPageToken_Key_Value()
+}
+
+type PageToken_Key_BoolValue struct {
+	BoolValue bool `protobuf:"varint,1,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type PageToken_Key_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type PageToken_Key_UintValue struct {
+	UintValue uint64 `protobuf:"varint,3,opt,name=uint_value,json=uintValue,proto3,oneof"`
+}
+
+type PageToken_Key_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type PageToken_Key_StringValue struct {
+	StringValue string `protobuf:"bytes,5,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type PageToken_Key_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,6,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type PageToken_Key_TimestampValue struct {
+	TimestampValue *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+type PageToken_Key_DurationValue struct {
+	DurationValue *durationpb.Duration `protobuf:"bytes,8,opt,name=duration_value,json=durationValue,proto3,oneof"`
+}
+
+func (*PageToken_Key_BoolValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_IntValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_UintValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_DoubleValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_StringValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_BytesValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_TimestampValue) isPageToken_Key_Value() {}
+
+func (*PageToken_Key_DurationValue) isPageToken_Key_Value() {}
+
+var File_einride_example_syntax_v1_pagetoken_proto protoreflect.FileDescriptor
+
+const file_einride_example_syntax_v1_pagetoken_proto_rawDesc = "" +
+	"\n" +
+	")einride/example/syntax/v1/pagetoken.proto\x12\x19einride.example.syntax.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\x04\n" +
+	"\tPageToken\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12)\n" +
+	"\x10request_checksum\x18\x02 \x01(\aR\x0frequestChecksum\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\rR\aversion\x12\x1d\n" +
+	"\n" +
+	"issue_time\x18\x04 \x01(\x03R\tissueTime\x12<\n" +
+	"\x04keys\x18\x05 \x03(\v2(.einride.example.syntax.v1.PageToken.KeyR\x04keys\x1a\xe7\x02\n" +
+	"\x03Key\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x01 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12\x1f\n" +
+	"\n" +
+	"uint_value\x18\x03 \x01(\x04H\x00R\tuintValue\x12#\n" +
+	"\fdouble_value\x18\x04 \x01(\x01H\x00R\vdoubleValue\x12#\n" +
+	"\fstring_value\x18\x05 \x01(\tH\x00R\vstringValue\x12!\n" +
+	"\vbytes_value\x18\x06 \x01(\fH\x00R\n" +
+	"bytesValue\x12E\n" +
+	"\x0ftimestamp_value\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0etimestampValue\x12B\n" +
+	"\x0eduration_value\x18\b \x01(\v2\x19.google.protobuf.DurationH\x00R\rdurationValueB\a\n" +
+	"\x05valueB\xf8\x01\n" +
+	"\x1dcom.einride.example.syntax.v1B\x0ePagetokenProtoP\x01Z@go.einride.tech/aip/proto/gen/einride/example/syntax/v1;syntaxv1\xa2\x02\x03EES\xaa\x02\x19Einride.Example.Syntax.V1\xca\x02\x19Einride\\Example\\Syntax\\V1\xe2\x02%Einride\\Example\\Syntax\\V1\\GPBMetadata\xea\x02\x1cEinride::Example::Syntax::V1b\x06proto3"
+
+var (
+	file_einride_example_syntax_v1_pagetoken_proto_rawDescOnce sync.Once
+	file_einride_example_syntax_v1_pagetoken_proto_rawDescData []byte
+)
+
+func file_einride_example_syntax_v1_pagetoken_proto_rawDescGZIP() []byte {
+	file_einride_example_syntax_v1_pagetoken_proto_rawDescOnce.Do(func() {
+		file_einride_example_syntax_v1_pagetoken_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_pagetoken_proto_rawDesc), len(file_einride_example_syntax_v1_pagetoken_proto_rawDesc)))
+	})
+	return file_einride_example_syntax_v1_pagetoken_proto_rawDescData
+}
+
+var file_einride_example_syntax_v1_pagetoken_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_einride_example_syntax_v1_pagetoken_proto_goTypes = []any{
+	(*PageToken)(nil),             // 0: einride.example.syntax.v1.PageToken
+	(*PageToken_Key)(nil),         // 1: einride.example.syntax.v1.PageToken.Key
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 3: google.protobuf.Duration
+}
+var file_einride_example_syntax_v1_pagetoken_proto_depIdxs = []int32{
+	1, // 0: einride.example.syntax.v1.PageToken.keys:type_name -> einride.example.syntax.v1.PageToken.Key
+	2, // 1: einride.example.syntax.v1.PageToken.Key.timestamp_value:type_name -> google.protobuf.Timestamp
+	3, // 2: einride.example.syntax.v1.PageToken.Key.duration_value:type_name -> google.protobuf.Duration
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_einride_example_syntax_v1_pagetoken_proto_init() }
+func file_einride_example_syntax_v1_pagetoken_proto_init() {
+	if File_einride_example_syntax_v1_pagetoken_proto != nil {
+		return
+	}
+	file_einride_example_syntax_v1_pagetoken_proto_msgTypes[1].OneofWrappers = []any{
+		(*PageToken_Key_BoolValue)(nil),
+		(*PageToken_Key_IntValue)(nil),
+		(*PageToken_Key_UintValue)(nil),
+		(*PageToken_Key_DoubleValue)(nil),
+		(*PageToken_Key_StringValue)(nil),
+		(*PageToken_Key_BytesValue)(nil),
+		(*PageToken_Key_TimestampValue)(nil),
+		(*PageToken_Key_DurationValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_pagetoken_proto_rawDesc), len(file_einride_example_syntax_v1_pagetoken_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_example_syntax_v1_pagetoken_proto_goTypes,
+		DependencyIndexes: file_einride_example_syntax_v1_pagetoken_proto_depIdxs,
+		MessageInfos:      file_einride_example_syntax_v1_pagetoken_proto_msgTypes,
+	}.Build()
+	File_einride_example_syntax_v1_pagetoken_proto = out.File
+	file_einride_example_syntax_v1_pagetoken_proto_goTypes = nil
+	file_einride_example_syntax_v1_pagetoken_proto_depIdxs = nil
+}
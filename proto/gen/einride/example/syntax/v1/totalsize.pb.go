@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: einride/example/syntax/v1/totalsize.proto
+
+package syntaxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Int32TotalSizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalSize     int32                  `protobuf:"varint,1,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int32TotalSizeResponse) Reset() {
+	*x = Int32TotalSizeResponse{}
+	mi := &file_einride_example_syntax_v1_totalsize_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int32TotalSizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int32TotalSizeResponse) ProtoMessage() {}
+
+func (x *Int32TotalSizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_totalsize_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int32TotalSizeResponse.ProtoReflect.Descriptor instead.
+func (*Int32TotalSizeResponse) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_totalsize_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Int32TotalSizeResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type Int64TotalSizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalSize     int64                  `protobuf:"varint,1,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int64TotalSizeResponse) Reset() {
+	*x = Int64TotalSizeResponse{}
+	mi := &file_einride_example_syntax_v1_totalsize_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int64TotalSizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int64TotalSizeResponse) ProtoMessage() {}
+
+func (x *Int64TotalSizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_totalsize_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int64TotalSizeResponse.ProtoReflect.Descriptor instead.
+func (*Int64TotalSizeResponse) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_totalsize_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Int64TotalSizeResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type StringTotalSizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalSize     string                 `protobuf:"bytes,1,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringTotalSizeResponse) Reset() {
+	*x = StringTotalSizeResponse{}
+	mi := &file_einride_example_syntax_v1_totalsize_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringTotalSizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringTotalSizeResponse) ProtoMessage() {}
+
+func (x *StringTotalSizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_example_syntax_v1_totalsize_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringTotalSizeResponse.ProtoReflect.Descriptor instead.
+func (*StringTotalSizeResponse) Descriptor() ([]byte, []int) {
+	return file_einride_example_syntax_v1_totalsize_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StringTotalSizeResponse) GetTotalSize() string {
+	if x != nil {
+		return x.TotalSize
+	}
+	return ""
+}
+
+var File_einride_example_syntax_v1_totalsize_proto protoreflect.FileDescriptor
+
+const file_einride_example_syntax_v1_totalsize_proto_rawDesc = "" +
+	"\n" +
+	")einride/example/syntax/v1/totalsize.proto\x12\x19einride.example.syntax.v1\"7\n" +
+	"\x16Int32TotalSizeResponse\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x01 \x01(\x05R\ttotalSize\"7\n" +
+	"\x16Int64TotalSizeResponse\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x01 \x01(\x03R\ttotalSize\"8\n" +
+	"\x17StringTotalSizeResponse\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x01 \x01(\tR\ttotalSizeB\xf8\x01\n" +
+	"\x1dcom.einride.example.syntax.v1B\x0eTotalsizeProtoP\x01Z@go.einride.tech/aip/proto/gen/einride/example/syntax/v1;syntaxv1\xa2\x02\x03EES\xaa\x02\x19Einride.Example.Syntax.V1\xca\x02\x19Einride\\Example\\Syntax\\V1\xe2\x02%Einride\\Example\\Syntax\\V1\\GPBMetadata\xea\x02\x1cEinride::Example::Syntax::V1b\x06proto3"
+
+var (
+	file_einride_example_syntax_v1_totalsize_proto_rawDescOnce sync.Once
+	file_einride_example_syntax_v1_totalsize_proto_rawDescData []byte
+)
+
+func file_einride_example_syntax_v1_totalsize_proto_rawDescGZIP() []byte {
+	file_einride_example_syntax_v1_totalsize_proto_rawDescOnce.Do(func() {
+		file_einride_example_syntax_v1_totalsize_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_totalsize_proto_rawDesc), len(file_einride_example_syntax_v1_totalsize_proto_rawDesc)))
+	})
+	return file_einride_example_syntax_v1_totalsize_proto_rawDescData
+}
+
+var file_einride_example_syntax_v1_totalsize_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_einride_example_syntax_v1_totalsize_proto_goTypes = []any{
+	(*Int32TotalSizeResponse)(nil),  // 0: einride.example.syntax.v1.Int32TotalSizeResponse
+	(*Int64TotalSizeResponse)(nil),  // 1: einride.example.syntax.v1.Int64TotalSizeResponse
+	(*StringTotalSizeResponse)(nil), // 2: einride.example.syntax.v1.StringTotalSizeResponse
+}
+var file_einride_example_syntax_v1_totalsize_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_einride_example_syntax_v1_totalsize_proto_init() }
+func file_einride_example_syntax_v1_totalsize_proto_init() {
+	if File_einride_example_syntax_v1_totalsize_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_einride_example_syntax_v1_totalsize_proto_rawDesc), len(file_einride_example_syntax_v1_totalsize_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_example_syntax_v1_totalsize_proto_goTypes,
+		DependencyIndexes: file_einride_example_syntax_v1_totalsize_proto_depIdxs,
+		MessageInfos:      file_einride_example_syntax_v1_totalsize_proto_msgTypes,
+	}.Build()
+	File_einride_example_syntax_v1_totalsize_proto = out.File
+	file_einride_example_syntax_v1_totalsize_proto_goTypes = nil
+	file_einride_example_syntax_v1_totalsize_proto_depIdxs = nil
+}